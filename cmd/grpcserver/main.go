@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/parse-address/pkg/config"
+	"github.com/parse-address/pkg/grpcserver"
+	"github.com/parse-address/pkg/parserpb"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.GRPCPort)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", addr, err)
+	}
+
+	// A bad custom pattern is skipped, not fatal
+	p, err := cfg.NewParser()
+	if err != nil {
+		log.Printf("Warning: %v; using the built-in patterns only", err)
+	}
+
+	srv := grpc.NewServer()
+	parserpb.RegisterAddressParserServer(srv, grpcserver.NewServer(p))
+
+	// Start server in a goroutine
+	go func() {
+		log.Printf("Starting gRPC server on %s", addr)
+		if err := srv.Serve(lis); err != nil {
+			log.Fatalf("Server failed: %v", err)
+		}
+	}()
+
+	// Wait for interrupt signal to gracefully shutdown the server
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down server...")
+	srv.GracefulStop()
+	log.Println("Server exited")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// wantsCamelCase reports whether the client asked for camelCase JSON keys
+// with ?keys=camel
+func wantsCamelCase(r *http.Request) bool {
+	return r.URL.Query().Get("keys") == "camel"
+}
+
+// respondCamelJSON is respondJSON with every object key converted from
+// snake_case to camelCase ("sec_unit_type" -> "secUnitType"). The struct
+// tags stay snake_case; the encoded value is rewritten instead.
+func respondCamelJSON(w http.ResponseWriter, status int, data interface{}) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		respondJSON(w, status, data)
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		respondJSON(w, status, data)
+		return
+	}
+	respondJSON(w, status, camelCaseKeys(value))
+}
+
+// camelCaseKeys converts the object keys in a decoded JSON value
+func camelCaseKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[camelCase(key)] = camelCaseKeys(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range v {
+			v[i] = camelCaseKeys(item)
+		}
+		return v
+	}
+	return value
+}
+
+// camelCase converts a snake_case name to camelCase
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
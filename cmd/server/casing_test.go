@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/parse-address/pkg/parser"
+)
+
+func TestParseHandlerKeyCase(t *testing.T) {
+	handler := parseHandler(parser.NewParser())
+	body := `{"address": "123 Main St Apt 4, Springfield, IL 62704"}`
+
+	tests := []struct {
+		name    string
+		query   string
+		wantKey string
+		absent  string
+	}{
+		{"Default snake_case", "", "sec_unit_type", "secUnitType"},
+		{"camelCase on request", "?keys=camel", "secUnitType", "sec_unit_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/parse"+tt.query, strings.NewReader(body)))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status: got %d, want %d", rec.Code, http.StatusOK)
+			}
+			var resp struct {
+				Result struct {
+					Address map[string]string `json:"address"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("response is not valid JSON: %v", err)
+			}
+			if got := resp.Result.Address[tt.wantKey]; got != "Apt" {
+				t.Errorf("address[%q]: got %q, want Apt", tt.wantKey, got)
+			}
+			if _, ok := resp.Result.Address[tt.absent]; ok {
+				t.Errorf("address[%q]: should be absent", tt.absent)
+			}
+		})
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"type":           "type",
+		"sec_unit_type2": "secUnitType2",
+		"runner_up":      "runnerUp",
+		"plus4":          "plus4",
+	}
+	for input, want := range tests {
+		if got := camelCase(input); got != want {
+			t.Errorf("camelCase(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -3,11 +3,17 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"log"
+	"math"
+	"mime"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -27,8 +33,11 @@ func main() {
 	log.Printf("Configuration: CORS=%v, RateLimit=%d/min, MaxInput=%d bytes",
 		cfg.Security.EnableCORS, cfg.Security.RateLimitPerMin, cfg.Security.MaxInputLength)
 
-	// Create parser instance
-	p := parser.NewParser()
+	// Create parser instance; a bad custom pattern is skipped, not fatal
+	p, err := cfg.NewParser()
+	if err != nil {
+		log.Printf("Warning: %v; using the built-in patterns only", err)
+	}
 
 	// Setup router
 	r := mux.NewRouter()
@@ -36,8 +45,10 @@ func main() {
 	// API routes
 	api := r.PathPrefix("/api/v1").Subrouter()
 	api.HandleFunc("/parse", parseHandler(p)).Methods("POST", "OPTIONS")
+	api.HandleFunc("/normalize", normalizeHandler(p)).Methods("POST", "OPTIONS")
 	api.HandleFunc("/health", healthHandler).Methods("GET")
 	api.HandleFunc("/config", configHandler(cfg)).Methods("GET")
+	api.HandleFunc("/openapi.json", openAPIHandler(openAPISpec())).Methods("GET")
 
 	// Static file server for GUI
 	r.HandleFunc("/", indexHandler).Methods("GET")
@@ -88,19 +99,41 @@ type parseRequest struct {
 	Type    string `json:"type,omitempty"` // "standard", "informal", "intersection", "po_box", "auto"
 }
 
+// apiError describes a failed request. Code is stable for clients to
+// branch on (see parser.ErrorCode); Message is for people.
+type apiError struct {
+	Code    string `json:"code" xml:"code"`
+	Message string `json:"message" xml:"message"`
+}
+
 type parseResponse struct {
-	Success bool                `json:"success"`
-	Error   string              `json:"error,omitempty"`
-	Result  *parser.ParseResult `json:"result,omitempty"`
+	XMLName     xml.Name                 `json:"-" xml:"response"`
+	Success     bool                     `json:"success" xml:"success"`
+	Error       *apiError                `json:"error,omitempty" xml:"error,omitempty"`
+	Result      *parser.ParseResult      `json:"result,omitempty" xml:"result,omitempty"`
+	Explanation *parser.ParseExplanation `json:"explanation,omitempty" xml:"explanation,omitempty"`         // Set with ?explain=true
+	Candidates  []*parser.ParseResult    `json:"candidates,omitempty" xml:"candidates>candidate,omitempty"` // Set with ?candidates=true
+
+	// DurationMicros is the time spent parsing, in microseconds rounded up,
+	// set with ?timing=true
+	DurationMicros int64 `json:"duration_micros,omitempty" xml:"duration_micros,omitempty"`
 }
 
 func parseHandler(p *parser.Parser) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		respondJSON := respondJSON
+		if wantsCamelCase(r) {
+			respondJSON = respondCamelJSON
+		}
+		if wantsXML(r) {
+			respondJSON = respondXML
+		}
+
 		var req parseRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			respondJSON(w, http.StatusBadRequest, parseResponse{
 				Success: false,
-				Error:   "Invalid request format",
+				Error:   &apiError{Code: "INVALID_REQUEST", Message: "Invalid request format"},
 			})
 			return
 		}
@@ -108,43 +141,78 @@ func parseHandler(p *parser.Parser) http.HandlerFunc {
 		if req.Address == "" {
 			respondJSON(w, http.StatusBadRequest, parseResponse{
 				Success: false,
-				Error:   "Address field is required",
+				Error:   &apiError{Code: parser.ErrorCode(parser.ErrInputEmpty), Message: "Address field is required"},
 			})
 			return
 		}
 
-		var result *parser.ParseResult
-		var err error
-
-		// Route to appropriate parser based on type
-		switch req.Type {
-		case "standard":
-			addr := p.ParseAddress(req.Address)
-			result = &parser.ParseResult{Type: "address", Address: addr}
-		case "informal":
-			addr := p.ParseInformalAddress(req.Address)
-			result = &parser.ParseResult{Type: "address", Address: addr}
-		case "intersection":
-			inter := p.ParseIntersection(req.Address)
-			result = &parser.ParseResult{Type: "intersection", Intersection: inter}
-		case "po_box":
-			addr := p.ParsePoAddress(req.Address)
-			result = &parser.ParseResult{Type: "po_box", Address: addr}
-		default: // "auto" or empty
-			result, err = p.ParseLocation(req.Address)
+		// Route to appropriate parser based on type. Pass the request context
+		// so a client disconnect stops parsing.
+		start := time.Now()
+		result, err := p.ParseAs(r.Context(), req.Address, req.Type)
+		elapsed := time.Since(start)
+
+		if err != nil && r.Context().Err() != nil {
+			// Client went away; nobody is left to read the response
+			return
 		}
 
 		if err != nil {
 			respondJSON(w, http.StatusBadRequest, parseResponse{
 				Success: false,
-				Error:   fmt.Sprintf("Parse error: %v", err),
+				Error:   &apiError{Code: parser.ErrorCode(err), Message: fmt.Sprintf("Parse error: %v", err)},
 			})
 			return
 		}
 
-		respondJSON(w, http.StatusOK, parseResponse{
+		resp := parseResponse{
 			Success: true,
 			Result:  result,
+		}
+		if r.URL.Query().Get("explain") == "true" {
+			resp.Explanation = p.Explain(req.Address)
+		}
+		if r.URL.Query().Get("candidates") == "true" {
+			resp.Candidates = p.ParseCandidates(req.Address)
+		}
+		if r.URL.Query().Get("timing") == "true" {
+			resp.DurationMicros = int64((elapsed + time.Microsecond - 1) / time.Microsecond)
+		}
+
+		respondJSON(w, http.StatusOK, resp)
+	}
+}
+
+type normalizeResponse struct {
+	Input      string    `json:"input"`
+	Normalized string    `json:"normalized,omitempty"`
+	Error      *apiError `json:"error,omitempty"`
+}
+
+// normalizeHandler returns the cleaned input without parsing it, applying
+// the parser's configured options
+func normalizeHandler(p *parser.Parser) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req parseRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondJSON(w, http.StatusBadRequest, normalizeResponse{
+				Error: &apiError{Code: "INVALID_REQUEST", Message: "Invalid request format"},
+			})
+			return
+		}
+
+		normalized, err := p.Normalize(req.Address)
+		if err != nil {
+			respondJSON(w, http.StatusBadRequest, normalizeResponse{
+				Input: req.Address,
+				Error: &apiError{Code: parser.ErrorCode(err), Message: err.Error()},
+			})
+			return
+		}
+
+		respondJSON(w, http.StatusOK, normalizeResponse{
+			Input:      req.Address,
+			Normalized: normalized,
 		})
 	}
 }
@@ -229,6 +297,39 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
+// wantsXML reports whether the client's Accept header ranks application/xml
+// or text/xml above JSON. An equal q-value goes to JSON, the default;
+// wildcards match both, so they decide nothing.
+func wantsXML(r *http.Request) bool {
+	var xmlQ, jsonQ float64
+	for _, entry := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(entry)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case "application/xml", "text/xml":
+			xmlQ = math.Max(xmlQ, q)
+		case "application/json":
+			jsonQ = math.Max(jsonQ, q)
+		}
+	}
+	return xmlQ > jsonQ
+}
+
+func respondXML(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	io.WriteString(w, xml.Header)
+	xml.NewEncoder(w).Encode(data)
+}
+
 // Embedded HTML for the web interface
 const indexHTML = `<!DOCTYPE html>
 <html lang="en">
@@ -398,6 +499,34 @@ const indexHTML = `<!DOCTYPE html>
         .badge-address { background: #bee3f8; color: #2c5282; }
         .badge-intersection { background: #fbd38d; color: #7c2d12; }
         .badge-po { background: #fbb6ce; color: #702459; }
+        .badge-highway { background: #c6f6d5; color: #22543d; }
+        .checkbox {
+            display: flex;
+            align-items: center;
+            gap: 8px;
+            margin-top: 15px;
+            font-weight: normal;
+        }
+        .checkbox input { width: auto; }
+        .steps { list-style: none; }
+        .steps li {
+            padding: 8px 0;
+            border-bottom: 1px solid #e2e8f0;
+            color: #2d3748;
+        }
+        .steps li:last-child { border-bottom: none; }
+        .step-stage {
+            display: inline-block;
+            min-width: 110px;
+            font-weight: 600;
+            color: #667eea;
+        }
+        .step-remaining {
+            display: block;
+            color: #718096;
+            font-size: 13px;
+            margin-left: 110px;
+        }
     </style>
 </head>
 <body>
@@ -420,6 +549,10 @@ const indexHTML = `<!DOCTYPE html>
                     <option value="po_box">PO Box</option>
                 </select>
 
+                <label class="checkbox" for="explain">
+                    <input type="checkbox" id="explain"> Explain (show each parse step)
+                </label>
+
                 <div class="button-group">
                     <button class="btn-primary" onclick="parseAddress()">Parse Address</button>
                     <button class="btn-secondary" onclick="clearResults()">Clear</button>
@@ -466,6 +599,7 @@ const indexHTML = `<!DOCTYPE html>
         async function parseAddress() {
             const address = document.getElementById('address').value.trim();
             const parseType = document.getElementById('parseType').value;
+            const explain = document.getElementById('explain').checked;
             const resultsDiv = document.getElementById('results');
 
             if (!address) {
@@ -476,7 +610,7 @@ const indexHTML = `<!DOCTYPE html>
             resultsDiv.innerHTML = '<div class="success">Parsing...</div>';
 
             try {
-                const response = await fetch('/api/v1/parse', {
+                const response = await fetch('/api/v1/parse' + (explain ? '?explain=true' : ''), {
                     method: 'POST',
                     headers: { 'Content-Type': 'application/json' },
                     body: JSON.stringify({ address, type: parseType })
@@ -485,17 +619,17 @@ const indexHTML = `<!DOCTYPE html>
                 const data = await response.json();
 
                 if (!data.success) {
-                    resultsDiv.innerHTML = '<div class="error">Error: ' + (data.error || 'Unknown error') + '</div>';
+                    resultsDiv.innerHTML = '<div class="error">Error: ' + (data.error ? data.error.message : 'Unknown error') + '</div>';
                     return;
                 }
 
-                displayResults(data.result);
+                displayResults(data.result, data.explanation);
             } catch (error) {
                 resultsDiv.innerHTML = '<div class="error">Network error: ' + error.message + '</div>';
             }
         }
 
-        function displayResults(result) {
+        function displayResults(result, explanation) {
             const resultsDiv = document.getElementById('results');
             let html = '<div class="results"><h2>Parse Results</h2>';
 
@@ -510,31 +644,70 @@ const indexHTML = `<!DOCTYPE html>
                 if (inter.city) html += formatResultItem('City', inter.city);
                 if (inter.state) html += formatResultItem('State', inter.state);
                 if (inter.zip) html += formatResultItem('ZIP', inter.zip);
+                if (inter.country) html += formatResultItem('Country', inter.country);
                 html += '</div>';
             } else if (result.address) {
-                const badge = result.type === 'po_box' ? 'badge-po' : 'badge-address';
-                const label = result.type === 'po_box' ? 'PO Box' : 'Address';
+                let badge = 'badge-address';
+                let label = 'Address';
+                if (result.type === 'po_box') {
+                    badge = 'badge-po';
+                    label = 'PO Box';
+                } else if (result.address.route_number) {
+                    badge = 'badge-highway';
+                    label = 'Highway';
+                }
                 html += '<span class="badge ' + badge + '">' + label + '</span>';
                 html += '<div class="result-card">';
                 const addr = result.address;
+                if (addr.attention) html += formatResultItem('Attention', addr.attention);
+                if (addr.care_of) html += formatResultItem('Care Of', addr.care_of);
+                if (addr.building_name) html += formatResultItem('Building', addr.building_name);
                 if (addr.number) html += formatResultItem('Number', addr.number);
+                if (addr.number_low) html += formatResultItem('Number Range', addr.number_low + ' to ' + addr.number_high);
                 if (addr.prefix) html += formatResultItem('Prefix', addr.prefix);
                 if (addr.street) html += formatResultItem('Street', addr.street);
                 if (addr.type) html += formatResultItem('Type', addr.type);
                 if (addr.suffix) html += formatResultItem('Suffix', addr.suffix);
                 if (addr.sec_unit_type) html += formatResultItem('Unit Type', addr.sec_unit_type);
                 if (addr.sec_unit_num) html += formatResultItem('Unit #', addr.sec_unit_num);
+                if (addr.sec_unit_type2) html += formatResultItem('Unit 2 Type', addr.sec_unit_type2);
+                if (addr.sec_unit_num2) html += formatResultItem('Unit 2 #', addr.sec_unit_num2);
                 if (addr.city) html += formatResultItem('City', addr.city);
+                if (addr.county) html += formatResultItem('County', addr.county);
                 if (addr.state) html += formatResultItem('State', addr.state);
                 if (addr.zip) html += formatResultItem('ZIP', addr.zip);
                 if (addr.plus4) html += formatResultItem('ZIP+4', addr.plus4);
+                if (addr.country) html += formatResultItem('Country', addr.country);
+                if (addr.route_number) html += formatResultItem('Route #', addr.route_number);
+                if (addr.parcel) html += formatResultItem('Parcel', addr.parcel);
                 html += '</div>';
             }
 
+            if (explanation) {
+                html += formatExplanation(explanation);
+            }
+
             html += '</div>';
             resultsDiv.innerHTML = html;
         }
 
+        function formatExplanation(explanation) {
+            let html = '<h2 style="margin-top: 20px;">Explanation</h2><div class="result-card"><ol class="steps">';
+            (explanation.steps || []).forEach(function(step) {
+                html += '<li><span class="step-stage">' + escapeHTML(step.stage) + '</span>' + escapeHTML(step.detail);
+                if (step.remaining) html += '<span class="step-remaining">left: ' + escapeHTML(step.remaining) + '</span>';
+                html += '</li>';
+            });
+            html += '</ol></div>';
+            return html;
+        }
+
+        function escapeHTML(text) {
+            const div = document.createElement('div');
+            div.textContent = text || '';
+            return div.innerHTML;
+        }
+
         function formatResultItem(label, value) {
             if (!value) return '';
             return '<div class="result-item"><div class="result-label">' + label + ':</div><div class="result-value">' + value + '</div></div>';
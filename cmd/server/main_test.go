@@ -0,0 +1,251 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/parse-address/pkg/parser"
+)
+
+func TestParseHandlerDetectedType(t *testing.T) {
+	rec := httptest.NewRecorder()
+	body := `{"address": "Main and Elm", "type": "standard"}`
+	parseHandler(parser.NewParser())(rec, httptest.NewRequest(http.MethodPost, "/api/v1/parse", strings.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want %d", rec.Code, http.StatusOK)
+	}
+	var resp parseResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response is not valid JSON: %v", err)
+	}
+	if resp.Result == nil || resp.Result.Type != "address" {
+		t.Fatalf("result: got %+v, want an address", resp.Result)
+	}
+	if resp.Result.DetectedType != "intersection" {
+		t.Errorf("detected_type: got %q, want intersection", resp.Result.DetectedType)
+	}
+	if len(resp.Result.Warnings) == 0 {
+		t.Error("warnings: want a type mismatch warning")
+	}
+}
+
+func TestParseHandlerCandidates(t *testing.T) {
+	rec := httptest.NewRecorder()
+	body := `{"address": "Main and Elm"}`
+	parseHandler(parser.NewParser())(rec, httptest.NewRequest(http.MethodPost, "/api/v1/parse?candidates=true", strings.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want %d", rec.Code, http.StatusOK)
+	}
+	var resp parseResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response is not valid JSON: %v", err)
+	}
+	if len(resp.Candidates) < 2 {
+		t.Fatalf("candidates: got %d, want at least 2", len(resp.Candidates))
+	}
+	if resp.Result == nil || resp.Candidates[0].Type != resp.Result.Type {
+		t.Errorf("first candidate: got %q, want the result type", resp.Candidates[0].Type)
+	}
+}
+
+func TestParseHandlerExplain(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want bool
+	}{
+		{"explain on", "/api/v1/parse?explain=true", true},
+		{"explain off", "/api/v1/parse", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			body := `{"address": "123 Main St, Springfield, IL 62701", "type": "standard"}`
+			parseHandler(parser.NewParser())(rec, httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(body)))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status: got %d, want %d", rec.Code, http.StatusOK)
+			}
+			var resp parseResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("response is not valid JSON: %v", err)
+			}
+			if got := resp.Explanation != nil; got != tt.want {
+				t.Fatalf("explanation present: got %v, want %v", got, tt.want)
+			}
+			if tt.want && len(resp.Explanation.Steps) == 0 {
+				t.Error("explanation: want at least one step")
+			}
+		})
+	}
+}
+
+func TestParseHandlerTiming(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want bool
+	}{
+		{"timing on", "/api/v1/parse?timing=true", true},
+		{"timing off", "/api/v1/parse", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			body := `{"address": "123 Main St, Springfield, IL 62701"}`
+			parseHandler(parser.NewParser())(rec, httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(body)))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status: got %d, want %d", rec.Code, http.StatusOK)
+			}
+			var resp map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("response is not valid JSON: %v", err)
+			}
+			duration, ok := resp["duration_micros"].(float64)
+			if ok != tt.want {
+				t.Fatalf("duration_micros present: got %v, want %v", ok, tt.want)
+			}
+			if tt.want && duration <= 0 {
+				t.Errorf("duration_micros: got %v, want positive", duration)
+			}
+		})
+	}
+}
+
+func TestParseHandlerXML(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/parse", strings.NewReader(`{"address": "123 Main St, Springfield, IL 62701"}`))
+	req.Header.Set("Accept", "application/xml")
+	parseHandler(parser.NewParser())(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want %d", rec.Code, http.StatusOK)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/xml" {
+		t.Errorf("content type: got %q, want application/xml", ct)
+	}
+	var resp parseResponse
+	if err := xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response is not valid XML: %v", err)
+	}
+	if !resp.Success || resp.Result == nil || resp.Result.Address == nil {
+		t.Fatalf("result: got %+v, want an address", resp.Result)
+	}
+	if resp.Result.Address.City != "Springfield" || resp.Result.Address.ZIP != "62701" {
+		t.Errorf("address: got %+v, want Springfield 62701", resp.Result.Address)
+	}
+}
+
+func TestWantsXML(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"application/xml", true},
+		{"text/xml; charset=utf-8", true},
+		{"application/json, application/xml;q=0.1", false},
+		{"application/json;q=0.5, application/xml", true},
+		{"application/xml, application/json", false},
+		{"application/xhtml+xml", false},
+		{"*/*", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/parse", nil)
+		req.Header.Set("Accept", tt.accept)
+		if got := wantsXML(req); got != tt.want {
+			t.Errorf("wantsXML(%q): got %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestParseHandlerErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode string
+	}{
+		{"Malformed JSON", `{"address":`, "INVALID_REQUEST"},
+		{"Empty address", `{"address": ""}`, "INPUT_EMPTY"},
+		{"Over-length address", `{"address": "` + strings.Repeat("A", parser.MaxInputLength+1) + `"}`, "INPUT_TOO_LONG"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			parseHandler(parser.NewParser())(rec, httptest.NewRequest(http.MethodPost, "/api/v1/parse", strings.NewReader(tt.body)))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status: got %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+			var resp parseResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("response is not valid JSON: %v", err)
+			}
+			if resp.Success || resp.Error == nil {
+				t.Fatalf("got %s, want an error", rec.Body.String())
+			}
+			if resp.Error.Code != tt.wantCode {
+				t.Errorf("code: got %q, want %q", resp.Error.Code, tt.wantCode)
+			}
+			if resp.Error.Message == "" {
+				t.Error("message: want a message")
+			}
+		})
+	}
+}
+
+func TestNormalizeHandler(t *testing.T) {
+	strict := parser.NewParserWithOptions(parser.ParseOptions{MaxSegments: 2})
+
+	tests := []struct {
+		name       string
+		parser     *parser.Parser
+		body       string
+		wantStatus int
+		wantOutput string
+		wantCode   string
+	}{
+		{"Cleaned", parser.NewParser(), `{"address": "  123  main st\t,springfield "}`, http.StatusOK, "123 MAIN ST, SPRINGFIELD", ""},
+		{"Empty address", parser.NewParser(), `{"address": ""}`, http.StatusBadRequest, "", "INPUT_EMPTY"},
+		{"Invalid JSON", parser.NewParser(), `{`, http.StatusBadRequest, "", "INVALID_REQUEST"},
+		{"Parser limits apply", strict, `{"address": "123 Main St, Springfield, IL"}`, http.StatusBadRequest, "", "TOO_MANY_SEGMENTS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			normalizeHandler(tt.parser)(rec, httptest.NewRequest(http.MethodPost, "/api/v1/normalize", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var resp normalizeResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("response is not valid JSON: %v", err)
+			}
+			if resp.Normalized != tt.wantOutput {
+				t.Errorf("normalized: got %q, want %q", resp.Normalized, tt.wantOutput)
+			}
+			if tt.wantCode == "" {
+				if resp.Error != nil {
+					t.Errorf("error: got %+v, want none", resp.Error)
+				}
+				return
+			}
+			if resp.Error == nil || resp.Error.Code != tt.wantCode || resp.Error.Message == "" {
+				t.Errorf("error: got %+v, want code %q with a message", resp.Error, tt.wantCode)
+			}
+		})
+	}
+}
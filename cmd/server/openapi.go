@@ -0,0 +1,189 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// openAPISpec builds the OpenAPI 3 document for the REST API. Schemas are
+// derived from the request and response structs through their json tags, so
+// the document follows the Go types as they change.
+func openAPISpec() map[string]interface{} {
+	schemas := map[string]interface{}{}
+	request := jsonSchema(reflect.TypeOf(parseRequest{}), schemas)
+	response := jsonSchema(reflect.TypeOf(parseResponse{}), schemas)
+	normalized := jsonSchema(reflect.TypeOf(normalizeResponse{}), schemas)
+
+	parseResponses := map[string]interface{}{
+		"200": jsonResponse("Parsed address", response),
+		"400": jsonResponse("Invalid request or address", response),
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "US Address Parser API",
+			"version": "v1",
+		},
+		"paths": map[string]interface{}{
+			"/api/v1/parse": map[string]interface{}{
+				"post": map[string]interface{}{
+					"summary": "Parse an address",
+					"parameters": []interface{}{
+						map[string]interface{}{
+							"name":        "explain",
+							"in":          "query",
+							"description": "Include the parsing steps as explanation",
+							"schema":      map[string]interface{}{"type": "boolean"},
+						},
+						map[string]interface{}{
+							"name":        "candidates",
+							"in":          "query",
+							"description": "Include every interpretation of the address, ranked by confidence",
+							"schema":      map[string]interface{}{"type": "boolean"},
+						},
+						map[string]interface{}{
+							"name":        "timing",
+							"in":          "query",
+							"description": "Include the parse time in microseconds as duration_micros",
+							"schema":      map[string]interface{}{"type": "boolean"},
+						},
+						map[string]interface{}{
+							"name":        "keys",
+							"in":          "query",
+							"description": "Key style of the response: camel for camelCase, snake_case otherwise",
+							"schema":      map[string]interface{}{"type": "string", "enum": []string{"snake", "camel"}},
+						},
+					},
+					"requestBody": map[string]interface{}{
+						"required": true,
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{"schema": request},
+						},
+					},
+					"responses": parseResponses,
+				},
+			},
+			"/api/v1/normalize": map[string]interface{}{
+				"post": map[string]interface{}{
+					"summary": "Clean up an address without parsing it",
+					"requestBody": map[string]interface{}{
+						"required": true,
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{"schema": request},
+						},
+					},
+					"responses": map[string]interface{}{
+						"200": jsonResponse("Normalized address", normalized),
+						"400": jsonResponse("Invalid request or address", normalized),
+					},
+				},
+			},
+			"/api/v1/health": map[string]interface{}{
+				"get": map[string]interface{}{
+					"summary":   "Health check",
+					"responses": map[string]interface{}{"200": jsonResponse("Server is healthy", map[string]interface{}{"type": "object"})},
+				},
+			},
+			"/api/v1/config": map[string]interface{}{
+				"get": map[string]interface{}{
+					"summary":   "Public server settings",
+					"responses": map[string]interface{}{"200": jsonResponse("Settings", map[string]interface{}{"type": "object"})},
+				},
+			},
+			"/api/v1/openapi.json": map[string]interface{}{
+				"get": map[string]interface{}{
+					"summary":   "This document",
+					"responses": map[string]interface{}{"200": jsonResponse("OpenAPI document", map[string]interface{}{"type": "object"})},
+				},
+			},
+		},
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+}
+
+func openAPIHandler(spec map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, spec)
+	}
+}
+
+func jsonResponse(description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// jsonSchema returns the schema for t. Structs are added to schemas under
+// their exported type name and referenced, which also handles recursive
+// types such as ParseResult.RunnerUp.
+func jsonSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return jsonSchema(t.Elem(), schemas)
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": jsonSchema(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchema(t.Elem(), schemas)}
+	case reflect.Struct:
+		name := exportedName(t.Name())
+		ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+		if _, ok := schemas[name]; ok {
+			return ref
+		}
+		// Register before walking the fields so recursion finds the ref
+		schema := map[string]interface{}{"type": "object"}
+		schemas[name] = schema
+
+		properties := map[string]interface{}{}
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			fieldName, opts, _ := strings.Cut(tag, ",")
+			if fieldName == "" {
+				fieldName = field.Name
+			}
+			properties[fieldName] = jsonSchema(field.Type, schemas)
+			if !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Ptr {
+				required = append(required, fieldName)
+			}
+		}
+		schema["properties"] = properties
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return ref
+	}
+	return map[string]interface{}{}
+}
+
+// exportedName upper-cases the first letter of a type name, so the
+// unexported parseRequest is published as ParseRequest
+func exportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenAPIHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openAPIHandler(openAPISpec())(rec, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var doc struct {
+		OpenAPI    string                     `json:"openapi"`
+		Paths      map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+				Required   []string                   `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("document is not valid JSON: %v", err)
+	}
+
+	if doc.OpenAPI != "3.0.3" {
+		t.Errorf("openapi: got %q, want 3.0.3", doc.OpenAPI)
+	}
+	if _, ok := doc.Paths["/api/v1/parse"]; !ok {
+		t.Error("paths: missing /api/v1/parse")
+	}
+
+	// Schemas follow the structs' json tags
+	request, ok := doc.Components.Schemas["ParseRequest"]
+	if !ok {
+		t.Fatal("schemas: missing ParseRequest")
+	}
+	if len(request.Required) != 1 || request.Required[0] != "address" {
+		t.Errorf("ParseRequest required: got %v, want [address]", request.Required)
+	}
+	for schema, property := range map[string]string{
+		"ParseResult":   "confidence",
+		"ParsedAddress": "sec_unit_type",
+		"ExplainStep":   "stage",
+	} {
+		if _, ok := doc.Components.Schemas[schema].Properties[property]; !ok {
+			t.Errorf("schema %s: missing property %q", schema, property)
+		}
+	}
+}
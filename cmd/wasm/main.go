@@ -0,0 +1,72 @@
+//go:build js && wasm
+
+// Command wasm exposes the address parser to JavaScript when compiled with
+// GOOS=js GOARCH=wasm, so the browser can parse without a server round trip.
+// It registers two global functions that take an address string and return
+// a JSON string shaped like the /api/v1/parse response:
+//
+//	ParseLocation("1005 N Gravenstein Hwy Sebastopol CA 95472")
+//	ParseAddress("123 Main St Apt 4B")
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/parse-address/pkg/parser"
+)
+
+type response struct {
+	Success bool                `json:"success"`
+	Error   string              `json:"error,omitempty"`
+	Result  *parser.ParseResult `json:"result,omitempty"`
+}
+
+func main() {
+	p := parser.NewParser()
+
+	js.Global().Set("ParseLocation", js.FuncOf(func(this js.Value, args []js.Value) any {
+		address, ok := addressArg(args)
+		if !ok {
+			return encode(response{Error: "address argument is required"})
+		}
+		result, err := p.ParseLocation(address)
+		if err != nil {
+			return encode(response{Error: err.Error()})
+		}
+		return encode(response{Success: true, Result: result})
+	}))
+
+	js.Global().Set("ParseAddress", js.FuncOf(func(this js.Value, args []js.Value) any {
+		address, ok := addressArg(args)
+		if !ok {
+			return encode(response{Error: "address argument is required"})
+		}
+		sanitized, err := parser.ValidateAndSanitize(address)
+		if err != nil {
+			return encode(response{Error: err.Error()})
+		}
+		addr := p.ParseAddress(sanitized)
+		return encode(response{Success: true, Result: &parser.ParseResult{Type: "address", Address: addr}})
+	}))
+
+	// Keep the Go runtime alive so the callbacks stay registered
+	select {}
+}
+
+// addressArg returns the first argument if it is a string
+func addressArg(args []js.Value) (string, bool) {
+	if len(args) == 0 || args[0].Type() != js.TypeString {
+		return "", false
+	}
+	return args[0].String(), true
+}
+
+// encode marshals r to a JSON string for the JavaScript caller
+func encode(r response) string {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return `{"success":false,"error":"failed to encode result"}`
+	}
+	return string(b)
+}
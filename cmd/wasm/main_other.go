@@ -0,0 +1,14 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// main explains how to build this command; it only runs in the browser
+func main() {
+	fmt.Fprintln(os.Stderr, "cmd/wasm must be built with GOOS=js GOARCH=wasm (see scripts/build-wasm.sh)")
+	os.Exit(1)
+}
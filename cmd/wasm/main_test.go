@@ -0,0 +1,29 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestWasmBuild compiles the package for js/wasm to catch imports that
+// break the browser build
+func TestWasmBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping wasm build in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found in PATH")
+	}
+
+	out := filepath.Join(t.TempDir(), "parser.wasm")
+	cmd := exec.Command(goBin, "build", "-o", out, ".")
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("js/wasm build failed: %v\n%s", err, output)
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"os"
 	"strconv"
 	"time"
+
+	"github.com/parse-address/pkg/parser"
 )
 
 // Config holds all application configuration
 type Config struct {
 	Server   ServerConfig
 	Security SecurityConfig
+	Parser   ParserConfig
 	Logging  LoggingConfig
 }
 
@@ -18,6 +21,7 @@ type Config struct {
 type ServerConfig struct {
 	Host            string
 	Port            int
+	GRPCPort        int
 	ReadTimeout     time.Duration
 	WriteTimeout    time.Duration
 	ShutdownTimeout time.Duration
@@ -30,6 +34,32 @@ type SecurityConfig struct {
 	AllowedOrigins  []string
 	RateLimitPerMin int
 	MaxInputLength  int
+	MaxSegments     int
+	MaxTokens       int
+	RejectEmoji     bool
+}
+
+// ParserConfig contains dictionary extensions for the address parser
+type ParserConfig struct {
+	// CustomStreetTypes maps extra street type abbreviations to their
+	// spelled-out names, from "chs=Chase,cls=Close"
+	CustomStreetTypes map[string]string
+
+	// ReturnEmptyOnNone gives results of type "none" an empty address
+	// instead of a nil one; off by default
+	ReturnEmptyOnNone bool
+
+	// QueensNumbers reads an unspaced hyphenated house number ("37-21")
+	// as one Queens-style number instead of a range; off by default
+	QueensNumbers bool
+
+	// PadZIP restores the leading zero of a 4-digit ZIP after a state code
+	// ("MA 1001" -> "01001"); off by default
+	PadZIP bool
+
+	// NotePattern is an extra regular expression for delivery notes. One
+	// that does not compile is logged and ignored.
+	NotePattern string
 }
 
 // LoggingConfig contains logging settings
@@ -44,6 +74,7 @@ func Load() (*Config, error) {
 		Server: ServerConfig{
 			Host:            getEnv("SERVER_HOST", "0.0.0.0"),
 			Port:            getEnvAsInt("SERVER_PORT", 8080),
+			GRPCPort:        getEnvAsInt("SERVER_GRPC_PORT", 9090),
 			ReadTimeout:     getEnvAsDuration("SERVER_READ_TIMEOUT", 10*time.Second),
 			WriteTimeout:    getEnvAsDuration("SERVER_WRITE_TIMEOUT", 10*time.Second),
 			ShutdownTimeout: getEnvAsDuration("SERVER_SHUTDOWN_TIMEOUT", 15*time.Second),
@@ -54,6 +85,16 @@ func Load() (*Config, error) {
 			AllowedOrigins:  getEnvAsSlice("SECURITY_ALLOWED_ORIGINS", []string{"*"}),
 			RateLimitPerMin: getEnvAsInt("SECURITY_RATE_LIMIT_PER_MIN", 60),
 			MaxInputLength:  getEnvAsInt("SECURITY_MAX_INPUT_LENGTH", 10000),
+			MaxSegments:     getEnvAsInt("SECURITY_MAX_SEGMENTS", 50),
+			MaxTokens:       getEnvAsInt("SECURITY_MAX_TOKENS", 500),
+			RejectEmoji:     getEnvAsBool("SECURITY_REJECT_EMOJI", false),
+		},
+		Parser: ParserConfig{
+			CustomStreetTypes: getEnvAsMap("PARSER_CUSTOM_STREET_TYPES"),
+			ReturnEmptyOnNone: getEnvAsBool("PARSER_RETURN_EMPTY_ON_NONE", false),
+			QueensNumbers:     getEnvAsBool("PARSER_QUEENS_NUMBERS", false),
+			PadZIP:            getEnvAsBool("PARSER_PAD_ZIP", false),
+			NotePattern:       getEnv("PARSER_NOTE_PATTERN", ""),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
@@ -74,6 +115,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid server port: %d (must be 1-65535)", c.Server.Port)
 	}
 
+	if c.Server.GRPCPort < 1 || c.Server.GRPCPort > 65535 {
+		return fmt.Errorf("invalid gRPC port: %d (must be 1-65535)", c.Server.GRPCPort)
+	}
+
 	if c.Server.ReadTimeout <= 0 {
 		return fmt.Errorf("read timeout must be positive")
 	}
@@ -86,6 +131,20 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("max input length must be between 100 and 100000")
 	}
 
+	if c.Security.MaxSegments < 1 {
+		return fmt.Errorf("max segments must be positive")
+	}
+
+	if c.Security.MaxTokens < 1 {
+		return fmt.Errorf("max tokens must be positive")
+	}
+
+	for abbr, name := range c.Parser.CustomStreetTypes {
+		if abbr == "" || name == "" {
+			return fmt.Errorf("invalid custom street type %q=%q: abbreviation and name are required", abbr, name)
+		}
+	}
+
 	validLogLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
 	if !validLogLevels[c.Logging.Level] {
 		return fmt.Errorf("invalid log level: %s (must be debug, info, warn, or error)", c.Logging.Level)
@@ -94,6 +153,26 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// NewParser builds the address parser the servers share from the parser
+// and security settings. The parser is always returned; a non-nil error
+// lists custom patterns it skipped, for the caller to log as a warning.
+func (c *Config) NewParser() (*parser.Parser, error) {
+	dicts := parser.Dictionaries{StreetTypes: c.Parser.CustomStreetTypes}
+	if c.Parser.NotePattern != "" {
+		dicts.NotePatterns = []string{c.Parser.NotePattern}
+	}
+
+	p := parser.NewParserWithDictionaries(parser.ParseOptions{
+		MaxSegments:       c.Security.MaxSegments,
+		MaxTokens:         c.Security.MaxTokens,
+		ReturnEmptyOnNone: c.Parser.ReturnEmptyOnNone,
+		QueensNumbers:     c.Parser.QueensNumbers,
+		PadZIP:            c.Parser.PadZIP,
+		RejectEmoji:       c.Security.RejectEmoji,
+	}, dicts)
+	return p, dicts.Validate()
+}
+
 // Helper functions for environment variable parsing
 
 func getEnv(key, defaultValue string) string {
@@ -173,6 +252,28 @@ func getEnvAsSlice(key string, defaultValue []string) []string {
 	return result
 }
 
+// getEnvAsMap reads comma-separated key=value pairs. Malformed entries are
+// skipped with a warning.
+func getEnvAsMap(key string) map[string]string {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return nil
+	}
+	result := map[string]string{}
+	for _, entry := range splitAndTrim(valueStr, ",") {
+		if entry == "" {
+			continue
+		}
+		pair := splitAndTrim(entry, "=")
+		if len(pair) != 2 {
+			fmt.Fprintf(os.Stderr, "Warning: invalid key=value entry for %s: %q, skipping\n", key, entry)
+			continue
+		}
+		result[pair[0]] = pair[1]
+	}
+	return result
+}
+
 func splitAndTrim(s, sep string) []string {
 	var result []string
 	for _, v := range splitString(s, sep) {
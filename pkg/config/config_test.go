@@ -1,9 +1,12 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
+
+	"github.com/parse-address/pkg/parser"
 )
 
 func TestLoad(t *testing.T) {
@@ -20,6 +23,10 @@ func TestLoad(t *testing.T) {
 		t.Errorf("Default port: got %d, want 8080", cfg.Server.Port)
 	}
 
+	if cfg.Server.GRPCPort != 9090 {
+		t.Errorf("Default gRPC port: got %d, want 9090", cfg.Server.GRPCPort)
+	}
+
 	if cfg.Server.Host != "0.0.0.0" {
 		t.Errorf("Default host: got %s, want 0.0.0.0", cfg.Server.Host)
 	}
@@ -27,6 +34,30 @@ func TestLoad(t *testing.T) {
 	if cfg.Security.MaxInputLength != 10000 {
 		t.Errorf("Default max input: got %d, want 10000", cfg.Security.MaxInputLength)
 	}
+
+	if cfg.Security.MaxSegments != 50 || cfg.Security.MaxTokens != 500 {
+		t.Errorf("Default limits: got %d segments, %d tokens, want 50, 500", cfg.Security.MaxSegments, cfg.Security.MaxTokens)
+	}
+
+	if cfg.Security.RejectEmoji {
+		t.Error("Default RejectEmoji: got true, want false")
+	}
+
+	if cfg.Parser.ReturnEmptyOnNone {
+		t.Error("Default ReturnEmptyOnNone: got true, want false")
+	}
+
+	if cfg.Parser.QueensNumbers {
+		t.Error("Default QueensNumbers: got true, want false")
+	}
+
+	if cfg.Parser.PadZIP {
+		t.Error("Default PadZIP: got true, want false")
+	}
+
+	if cfg.Parser.NotePattern != "" {
+		t.Errorf("Default NotePattern: got %q, want none", cfg.Parser.NotePattern)
+	}
 }
 
 func TestLoadWithCustomValues(t *testing.T) {
@@ -35,6 +66,12 @@ func TestLoadWithCustomValues(t *testing.T) {
 	os.Setenv("SERVER_HOST", "127.0.0.1")
 	os.Setenv("SECURITY_MAX_INPUT_LENGTH", "5000")
 	os.Setenv("LOG_LEVEL", "debug")
+	os.Setenv("PARSER_CUSTOM_STREET_TYPES", "trce=Trace, cv = Cove")
+	os.Setenv("PARSER_RETURN_EMPTY_ON_NONE", "true")
+	os.Setenv("SECURITY_REJECT_EMOJI", "true")
+	os.Setenv("PARSER_QUEENS_NUMBERS", "true")
+	os.Setenv("PARSER_PAD_ZIP", "true")
+	os.Setenv("PARSER_NOTE_PATTERN", "(?i)beware of dog")
 
 	cfg, err := Load()
 	if err != nil {
@@ -56,6 +93,31 @@ func TestLoadWithCustomValues(t *testing.T) {
 	if cfg.Logging.Level != "debug" {
 		t.Errorf("Custom log level: got %s, want debug", cfg.Logging.Level)
 	}
+
+	types := cfg.Parser.CustomStreetTypes
+	if len(types) != 2 || types["trce"] != "Trace" || types["cv"] != "Cove" {
+		t.Errorf("Custom street types: got %v, want map[cv:Cove trce:Trace]", types)
+	}
+
+	if !cfg.Parser.ReturnEmptyOnNone {
+		t.Error("Custom ReturnEmptyOnNone: got false, want true")
+	}
+
+	if !cfg.Security.RejectEmoji {
+		t.Error("Custom RejectEmoji: got false, want true")
+	}
+
+	if !cfg.Parser.QueensNumbers {
+		t.Error("Custom QueensNumbers: got false, want true")
+	}
+
+	if !cfg.Parser.PadZIP {
+		t.Error("Custom PadZIP: got false, want true")
+	}
+
+	if cfg.Parser.NotePattern != "(?i)beware of dog" {
+		t.Errorf("Custom NotePattern: got %q, want %q", cfg.Parser.NotePattern, "(?i)beware of dog")
+	}
 }
 
 func TestValidation(t *testing.T) {
@@ -69,11 +131,14 @@ func TestValidation(t *testing.T) {
 			config: Config{
 				Server: ServerConfig{
 					Port:         8080,
+					GRPCPort:     9090,
 					ReadTimeout:  10 * time.Second,
 					WriteTimeout: 10 * time.Second,
 				},
 				Security: SecurityConfig{
 					MaxInputLength: 1000,
+					MaxSegments:    50,
+					MaxTokens:      500,
 				},
 				Logging: LoggingConfig{
 					Level: "info",
@@ -86,11 +151,14 @@ func TestValidation(t *testing.T) {
 			config: Config{
 				Server: ServerConfig{
 					Port:         0,
+					GRPCPort:     9090,
 					ReadTimeout:  10 * time.Second,
 					WriteTimeout: 10 * time.Second,
 				},
 				Security: SecurityConfig{
 					MaxInputLength: 1000,
+					MaxSegments:    50,
+					MaxTokens:      500,
 				},
 				Logging: LoggingConfig{
 					Level: "info",
@@ -103,11 +171,34 @@ func TestValidation(t *testing.T) {
 			config: Config{
 				Server: ServerConfig{
 					Port:         99999,
+					GRPCPort:     9090,
+					ReadTimeout:  10 * time.Second,
+					WriteTimeout: 10 * time.Second,
+				},
+				Security: SecurityConfig{
+					MaxInputLength: 1000,
+					MaxSegments:    50,
+					MaxTokens:      500,
+				},
+				Logging: LoggingConfig{
+					Level: "info",
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "Invalid gRPC port",
+			config: Config{
+				Server: ServerConfig{
+					Port:         8080,
+					GRPCPort:     0,
 					ReadTimeout:  10 * time.Second,
 					WriteTimeout: 10 * time.Second,
 				},
 				Security: SecurityConfig{
 					MaxInputLength: 1000,
+					MaxSegments:    50,
+					MaxTokens:      500,
 				},
 				Logging: LoggingConfig{
 					Level: "info",
@@ -120,11 +211,14 @@ func TestValidation(t *testing.T) {
 			config: Config{
 				Server: ServerConfig{
 					Port:         8080,
+					GRPCPort:     9090,
 					ReadTimeout:  0,
 					WriteTimeout: 10 * time.Second,
 				},
 				Security: SecurityConfig{
 					MaxInputLength: 1000,
+					MaxSegments:    50,
+					MaxTokens:      500,
 				},
 				Logging: LoggingConfig{
 					Level: "info",
@@ -137,11 +231,57 @@ func TestValidation(t *testing.T) {
 			config: Config{
 				Server: ServerConfig{
 					Port:         8080,
+					GRPCPort:     9090,
 					ReadTimeout:  10 * time.Second,
 					WriteTimeout: 10 * time.Second,
 				},
 				Security: SecurityConfig{
 					MaxInputLength: 50,
+					MaxSegments:    50,
+					MaxTokens:      500,
+				},
+				Logging: LoggingConfig{
+					Level: "info",
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "Invalid max segments",
+			config: Config{
+				Server: ServerConfig{
+					Port:         8080,
+					GRPCPort:     9090,
+					ReadTimeout:  10 * time.Second,
+					WriteTimeout: 10 * time.Second,
+				},
+				Security: SecurityConfig{
+					MaxInputLength: 1000,
+					MaxSegments:    0,
+					MaxTokens:      500,
+				},
+				Logging: LoggingConfig{
+					Level: "info",
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "Invalid custom street type",
+			config: Config{
+				Server: ServerConfig{
+					Port:         8080,
+					GRPCPort:     9090,
+					ReadTimeout:  10 * time.Second,
+					WriteTimeout: 10 * time.Second,
+				},
+				Security: SecurityConfig{
+					MaxInputLength: 1000,
+					MaxSegments:    50,
+					MaxTokens:      500,
+				},
+				Parser: ParserConfig{
+					CustomStreetTypes: map[string]string{"trce": ""},
 				},
 				Logging: LoggingConfig{
 					Level: "info",
@@ -154,11 +294,14 @@ func TestValidation(t *testing.T) {
 			config: Config{
 				Server: ServerConfig{
 					Port:         8080,
+					GRPCPort:     9090,
 					ReadTimeout:  10 * time.Second,
 					WriteTimeout: 10 * time.Second,
 				},
 				Security: SecurityConfig{
 					MaxInputLength: 1000,
+					MaxSegments:    50,
+					MaxTokens:      500,
 				},
 				Logging: LoggingConfig{
 					Level: "invalid",
@@ -226,6 +369,22 @@ func TestGetEnvAsBool(t *testing.T) {
 	}
 }
 
+func TestGetEnvAsMap(t *testing.T) {
+	os.Clearenv()
+
+	// Test unset
+	if result := getEnvAsMap("NONEXISTENT"); result != nil {
+		t.Errorf("Unset: got %v, want nil", result)
+	}
+
+	// Test malformed entries are skipped
+	os.Setenv("TEST_MAP", "trce=Trace,bogus,cv=Cove,a=b=c")
+	result := getEnvAsMap("TEST_MAP")
+	if len(result) != 2 || result["trce"] != "Trace" || result["cv"] != "Cove" {
+		t.Errorf("Pairs: got %v, want map[cv:Cove trce:Trace]", result)
+	}
+}
+
 func TestGetEnvAsDuration(t *testing.T) {
 	os.Clearenv()
 
@@ -249,3 +408,31 @@ func TestGetEnvAsDuration(t *testing.T) {
 		t.Errorf("Invalid duration fallback: got %v, want 5s", result)
 	}
 }
+
+func TestNewParser(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("PARSER_CUSTOM_STREET_TYPES", "chs=Chase")
+	os.Setenv("SECURITY_MAX_SEGMENTS", "2")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	p, err := cfg.NewParser()
+	if err != nil {
+		t.Fatalf("NewParser() failed: %v", err)
+	}
+	if got := p.ParseAddress("12 Elm Chase").Type; got != "chs" {
+		t.Errorf("Custom street type: got %q, want chs", got)
+	}
+	if _, err := p.ParseLocation("123 Main St, Springfield, IL"); !errors.Is(err, parser.ErrTooManySegments) {
+		t.Errorf("Segment limit: got error %v, want ErrTooManySegments", err)
+	}
+
+	// A bad note pattern is reported but still gives a parser
+	cfg.Parser.NotePattern = "("
+	p, err = cfg.NewParser()
+	if !errors.Is(err, parser.ErrInvalidPattern) || p == nil {
+		t.Errorf("Invalid note pattern: got %v, %v, want a parser and ErrInvalidPattern", p, err)
+	}
+}
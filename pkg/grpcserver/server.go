@@ -0,0 +1,139 @@
+// Package grpcserver exposes the address parser as the parser.v1.AddressParser
+// gRPC service.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/parse-address/pkg/parser"
+	"github.com/parse-address/pkg/parserpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements parserpb.AddressParserServer on top of a parser.Parser
+type Server struct {
+	parserpb.UnimplementedAddressParserServer
+
+	parser *parser.Parser
+}
+
+// NewServer creates a gRPC service backed by p
+func NewServer(p *parser.Parser) *Server {
+	return &Server{parser: p}
+}
+
+// Parse parses a single address
+func (s *Server) Parse(ctx context.Context, req *parserpb.ParseRequest) (*parserpb.ParseResponse, error) {
+	if req.GetAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "address is required")
+	}
+
+	result, err := s.parser.ParseAs(ctx, req.GetAddress(), req.GetType())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &parserpb.ParseResponse{Result: toProtoResult(result)}, nil
+}
+
+// ParseBatch parses the requested page of addresses, keeping input order
+func (s *Server) ParseBatch(ctx context.Context, req *parserpb.ParseBatchRequest) (*parserpb.ParseBatchResponse, error) {
+	page, err := s.parser.ParseBatchPage(ctx, req.GetAddresses(), int(req.GetLimit()), int(req.GetOffset()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &parserpb.ParseBatchResponse{
+		Results: make([]*parserpb.ParseResult, len(page.Results)),
+		Total:   int32(page.Total),
+	}
+	for i, r := range page.Results {
+		resp.Results[i] = toProtoResult(r)
+	}
+	return resp, nil
+}
+
+// toStatus maps parser and context errors onto gRPC status codes
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func toProtoResult(r *parser.ParseResult) *parserpb.ParseResult {
+	if r == nil {
+		return nil
+	}
+	return &parserpb.ParseResult{
+		Type:          r.Type,
+		Address:       toProtoAddress(r.Address),
+		Intersection:  toProtoIntersection(r.Intersection),
+		Confidence:    r.Confidence,
+		RunnerUp:      toProtoResult(r.RunnerUp),
+		Raw:           toProtoAddress(r.Raw),
+		Partial:       r.Partial,
+		Warnings:      r.Warnings,
+		DetectedType:  r.DetectedType,
+		Undeliverable: r.Undeliverable,
+		Phone:         r.Phone,
+		Email:         r.Email,
+		Notes:         r.Notes,
+	}
+}
+
+func toProtoAddress(a *parser.ParsedAddress) *parserpb.ParsedAddress {
+	if a == nil {
+		return nil
+	}
+	return &parserpb.ParsedAddress{
+		Attention:    a.Attention,
+		CareOf:       a.CareOf,
+		BuildingName: a.BuildingName,
+		Number:       a.Number,
+		NumberLow:    a.NumberLow,
+		NumberHigh:   a.NumberHigh,
+		Prefix:       a.Prefix,
+		Street:       a.Street,
+		Type:         a.Type,
+		Suffix:       a.Suffix,
+		SecUnitType:  a.SecUnitType,
+		SecUnitNum:   a.SecUnitNum,
+		SecUnitType2: a.SecUnitType2,
+		SecUnitNum2:  a.SecUnitNum2,
+		Parcel:       a.Parcel,
+		RouteNumber:  a.RouteNumber,
+		City:         a.City,
+		County:       a.County,
+		State:        a.State,
+		Zip:          a.ZIP,
+		Plus4:        a.Plus4,
+		Country:      a.Country,
+	}
+}
+
+func toProtoIntersection(i *parser.ParsedIntersection) *parserpb.ParsedIntersection {
+	if i == nil {
+		return nil
+	}
+	return &parserpb.ParsedIntersection{
+		Prefix1: i.Prefix1,
+		Street1: i.Street1,
+		Type1:   i.Type1,
+		Suffix1: i.Suffix1,
+		Prefix2: i.Prefix2,
+		Street2: i.Street2,
+		Type2:   i.Type2,
+		Suffix2: i.Suffix2,
+		City:    i.City,
+		State:   i.State,
+		Zip:     i.ZIP,
+		Country: i.Country,
+	}
+}
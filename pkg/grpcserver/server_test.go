@@ -0,0 +1,196 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/parse-address/pkg/parser"
+	"github.com/parse-address/pkg/parserpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient starts the service on an in-memory listener and returns a
+// client connected to it
+func newTestClient(t *testing.T) parserpb.AddressParserClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	parserpb.RegisterAddressParserServer(srv, NewServer(parser.NewParser()))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return parserpb.NewAddressParserClient(conn)
+}
+
+func TestParse(t *testing.T) {
+	client := newTestClient(t)
+
+	tests := []struct {
+		name       string
+		req        *parserpb.ParseRequest
+		wantType   string
+		wantStreet string
+		wantCity   string
+	}{
+		{
+			name:       "Auto standard address",
+			req:        &parserpb.ParseRequest{Address: "1005 N Gravenstein Hwy, Sebastopol, CA 95472"},
+			wantType:   "address",
+			wantStreet: "Gravenstein",
+			wantCity:   "Sebastopol",
+		},
+		{
+			name:       "Explicit PO box",
+			req:        &parserpb.ParseRequest{Address: "PO Box 1234, Denver, CO 80201", Type: "po_box"},
+			wantType:   "po_box",
+			wantStreet: "",
+			wantCity:   "Denver",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.Parse(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+			result := resp.GetResult()
+			if result.GetType() != tt.wantType {
+				t.Errorf("Type: got %q, want %q", result.GetType(), tt.wantType)
+			}
+			if got := result.GetAddress().GetStreet(); got != tt.wantStreet {
+				t.Errorf("Street: got %q, want %q", got, tt.wantStreet)
+			}
+			if got := result.GetAddress().GetCity(); got != tt.wantCity {
+				t.Errorf("City: got %q, want %q", got, tt.wantCity)
+			}
+		})
+	}
+}
+
+func TestParseIntersection(t *testing.T) {
+	client := newTestClient(t)
+
+	resp, err := client.Parse(context.Background(), &parserpb.ParseRequest{
+		Address: "Mission St and Valencia St, San Francisco, CA",
+	})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	inter := resp.GetResult().GetIntersection()
+	if inter.GetStreet1() != "Mission" || inter.GetStreet2() != "Valencia" {
+		t.Errorf("Streets: got %q/%q, want Mission/Valencia", inter.GetStreet1(), inter.GetStreet2())
+	}
+}
+
+func TestParseDiagnostics(t *testing.T) {
+	client := newTestClient(t)
+
+	resp, err := client.Parse(context.Background(), &parserpb.ParseRequest{Address: "Main and Elm", Type: "standard"})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	result := resp.GetResult()
+	if result.GetDetectedType() != "intersection" {
+		t.Errorf("DetectedType: got %q, want intersection", result.GetDetectedType())
+	}
+	if len(result.GetWarnings()) == 0 {
+		t.Error("Warnings: want a type mismatch warning")
+	}
+
+	resp, err = client.Parse(context.Background(), &parserpb.ParseRequest{
+		Address: "123 Main St, Springfield, IL 62704 (leave at back door)",
+	})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if resp.GetResult().GetNotes() == "" {
+		t.Error("Notes: want the delivery note")
+	}
+}
+
+func TestParseInvalidArgument(t *testing.T) {
+	client := newTestClient(t)
+
+	for _, address := range []string{"", "123 Main\x00St"} {
+		_, err := client.Parse(context.Background(), &parserpb.ParseRequest{Address: address})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Parse(%q): got code %v, want %v", address, status.Code(err), codes.InvalidArgument)
+		}
+	}
+}
+
+func TestParseBatch(t *testing.T) {
+	client := newTestClient(t)
+
+	resp, err := client.ParseBatch(context.Background(), &parserpb.ParseBatchRequest{
+		Addresses: []string{
+			"123 Main St, Springfield, IL 62701",
+			"",
+			"PO Box 99, Austin, TX 78701",
+		},
+	})
+	if err != nil {
+		t.Fatalf("ParseBatch() failed: %v", err)
+	}
+
+	want := []string{"address", "none", "po_box"}
+	results := resp.GetResults()
+	if len(results) != len(want) {
+		t.Fatalf("Results: got %d, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		if results[i].GetType() != w {
+			t.Errorf("Result %d type: got %q, want %q", i, results[i].GetType(), w)
+		}
+	}
+}
+
+func TestParseBatchPage(t *testing.T) {
+	client := newTestClient(t)
+	addresses := []string{
+		"123 Main St, Springfield, IL 62701",
+		"",
+		"PO Box 99, Austin, TX 78701",
+	}
+
+	resp, err := client.ParseBatch(context.Background(), &parserpb.ParseBatchRequest{
+		Addresses: addresses,
+		Limit:     1,
+		Offset:    2,
+	})
+	if err != nil {
+		t.Fatalf("ParseBatch() failed: %v", err)
+	}
+	if resp.GetTotal() != 3 {
+		t.Errorf("Total: got %d, want 3", resp.GetTotal())
+	}
+	if len(resp.GetResults()) != 1 || resp.GetResults()[0].GetType() != "po_box" {
+		t.Errorf("Results: got %v, want one po_box", resp.GetResults())
+	}
+
+	_, err = client.ParseBatch(context.Background(), &parserpb.ParseBatchRequest{
+		Addresses: addresses,
+		Offset:    -1,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("negative offset: got %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"strings"
+)
+
+// parseCanonical is a fast path for input already in the canonical
+// "123 Main St, City, ST 12345" shape: a house number, street name and
+// street type; a city; then a state code and ZIP. The fields are sliced out
+// directly instead of going through the full pipeline. It returns nil for
+// any other shape, or for anything the full parser treats specially
+// (directionals, units, locales), so the caller can fall back.
+func (p *Parser) parseCanonical(address string) *ParsedAddress {
+	if p.options.Locale != "" || p.options.SplitGluedTokens {
+		return nil
+	}
+
+	parts := strings.Split(address, ",")
+	if len(parts) != 3 {
+		return nil
+	}
+	street := strings.Fields(parts[0])
+	city := strings.Fields(parts[1])
+	last := strings.Fields(parts[2])
+	if len(street) < 3 || len(city) == 0 || len(last) != 2 {
+		return nil
+	}
+
+	number, name, streetType := street[0], street[1:len(street)-1], street[len(street)-1]
+	if !isDigits(number) || !isLetters(streetType) || !p.isStreetType(streetType) {
+		return nil
+	}
+	for _, word := range name {
+		if !isStreetWord(word) || NormalizeDirectional(word) != "" {
+			return nil
+		}
+	}
+	for _, word := range city {
+		if !isLetters(word) {
+			return nil
+		}
+	}
+
+	state := p.stateAbbrev(last[0])
+	zip, plus4 := last[1], ""
+	if len(zip) == 10 && zip[5] == '-' {
+		zip, plus4 = zip[:5], zip[6:]
+	}
+	if state == "" || len(zip) != 5 || !isDigits(zip) || !isDigits(plus4) || !plausibleZIP(zip) {
+		return nil
+	}
+
+	// Unit keywords and unit words ("Front Royal") need the full parser, as
+	// does a county in place of the city ("Sonoma County")
+	if p.patterns.secUnit.MatchString(parts[0]) || p.patterns.secUnit.MatchString(parts[1]) ||
+		p.patterns.county.MatchString(parts[1]) {
+		return nil
+	}
+
+	result := &ParsedAddress{
+		Number: number,
+		Street: strings.Join(name, " "),
+		Type:   p.normalizeStreetType(streetType),
+		City:   strings.Join(city, " "),
+		State:  state,
+		ZIP:    zip,
+		Plus4:  plus4,
+	}
+	result.Normalize()
+	return result
+}
+
+// isDigits reports whether s holds only ASCII digits; "" counts
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isLetters reports whether s is a non-empty run of ASCII letters
+func isLetters(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isStreetWord reports whether s is an ASCII word of letters and digits
+// holding at least one letter ("Main", "5th")
+func isStreetWord(s string) bool {
+	letter := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+		case c|0x20 >= 'a' && c|0x20 <= 'z':
+			letter = true
+		default:
+			return false
+		}
+	}
+	return letter
+}
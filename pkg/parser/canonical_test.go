@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestParseAddressCanonicalFastPath(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name  string
+		input string
+		fast  bool
+	}{
+		{"Canonical", "123 Main St, Springfield, IL 62701", true},
+		{"ZIP+4 and multi-word names", "768 Fifth Avenue Park Ave, New York City, ny 10019-1234", true},
+		{"Ordinal street", "100 5th Ave, New York, NY 10011", true},
+		{"Directional prefix", "1005 N Gravenstein Hwy, Sebastopol, CA 95472", false},
+		{"Unit in the street line", "123 Main St Apt 4, Springfield, IL 62701", false},
+		{"Unit word as city", "123 Main St, Front Royal, VA 22630", false},
+		{"No street type", "123 Main, Springfield, IL 62701", false},
+		{"Unknown state", "123 Main St, Springfield, ZZ 62701", false},
+		{"Implausible ZIP", "123 Main St, Springfield, IL 00012", false},
+		{"Four segments", "The Plaza, 768 5th Ave, New York, NY 10019", false},
+		{"County segment", "123 Main St, Sonoma County, CA 95472", false},
+		{"County of segment", "123 Main St, County of Sonoma, CA 95472", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if fast := p.parseCanonical(tt.input) != nil; fast != tt.fast {
+				t.Errorf("fast path taken: got %v, want %v", fast, tt.fast)
+			}
+			slow, _ := p.parseAddress(tt.input, nil)
+			if got := p.ParseAddress(tt.input); *got != *slow {
+				t.Errorf("got %+v, want %+v (full parser)", *got, *slow)
+			}
+		})
+	}
+}
+
+func BenchmarkParseAddressCanonical(b *testing.B) {
+	p := NewParser()
+	addr := "123 Main St, Springfield, IL 62701"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.ParseAddress(addr)
+	}
+}
+
+// BenchmarkParseAddressCanonicalFullParser parses the same input without the
+// fast path, for comparison
+func BenchmarkParseAddressCanonicalFullParser(b *testing.B) {
+	p := NewParser()
+	addr := "123 Main St, Springfield, IL 62701"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.parseAddress(addr, nil)
+	}
+}
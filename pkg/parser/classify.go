@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"strings"
+)
+
+// DetectType guesses the result type ParseLocation will return ("address",
+// "intersection" or "po_box") from the routing patterns alone, without
+// parsing the components. It is a heuristic: ParseLocation scores the
+// parsed candidates and may disagree ("Box 45, Town ST" is a "po_box" here
+// but an "address" there). Input that fails validation gives "none".
+func (p *Parser) DetectType(address string) string {
+	sanitized, err := p.sanitize(address)
+	if err != nil {
+		return "none"
+	}
+	switch {
+	case p.isPoBox(sanitized):
+		return "po_box"
+	case p.hasCorner(sanitized) && !p.patterns.number.MatchString(sanitized):
+		return "intersection"
+	}
+	return "address"
+}
+
+// quickPoBoxPrefixes open a PO box for QuickClassify, after lower-casing
+var quickPoBoxPrefixes = []string{
+	"po box", "p.o. box", "p.o.box", "p o box", "pobox", "post office box",
+	"box ", "general delivery",
+}
+
+// quickCornerMarkers mark an intersection for QuickClassify
+var quickCornerMarkers = []string{" and ", " at ", "&", "@", " / ", " x "}
+
+// QuickClassify is a cheaper DetectType for routing large volumes: it uses
+// only prefix and substring checks, with no regular expressions and no input
+// validation beyond emptiness.
+//
+// It agrees with DetectType on clear input but gives up accuracy at the
+// edges: a PO box is only found at the start of the input ("Mail: PO Box
+// 12" is an "address"), a bare "Box" is a PO box even without a state, and
+// case or spacing variants the patterns accept ("P O  Box", "AND") may be
+// missed.
+func QuickClassify(address string) string {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "none"
+	}
+
+	head := address
+	if len(head) > 20 {
+		head = head[:20]
+	}
+	head = strings.ToLower(head)
+	for _, prefix := range quickPoBoxPrefixes {
+		if strings.HasPrefix(head, prefix) {
+			return "po_box"
+		}
+	}
+
+	// A leading house number means the marker is part of a street or place
+	// name ("10 Bread and Butter Ln")
+	if c := address[0]; c >= '0' && c <= '9' {
+		return "address"
+	}
+	for _, marker := range quickCornerMarkers {
+		if strings.Contains(address, marker) {
+			return "intersection"
+		}
+	}
+	return "address"
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestQuickClassify(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1005 N Gravenstein Hwy, Sebastopol, CA 95472", "address"},
+		{"123 Main St Apt 4B, Springfield, IL 62701", "address"},
+		{"10 Bread and Butter Ln", "address"},
+		{"PO Box 1234, Denver, CO 80201", "po_box"},
+		{"P.O. Box 99, Austin, TX 78701", "po_box"},
+		{"Post Office Box 5, Boston, MA", "po_box"},
+		{"General Delivery, Juneau, AK 99801", "po_box"},
+		{"Mission St and Valencia St, San Francisco, CA", "intersection"},
+		{"Hollywood Blvd & Vine St", "intersection"},
+		{"Main St at Elm St", "intersection"},
+		{"Mission St / Valencia St", "intersection"},
+		{"Mission x Valencia", "intersection"},
+		{"", "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := QuickClassify(tt.input); got != tt.expected {
+				t.Errorf("QuickClassify: got %q, want %q", got, tt.expected)
+			}
+			if got := p.DetectType(tt.input); got != tt.expected {
+				t.Errorf("DetectType: got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	// Documented disagreement: QuickClassify only looks for a PO box at the
+	// start
+	if got := QuickClassify("Mail: P.O. Box 12, Town ST 12345"); got != "address" {
+		t.Errorf("QuickClassify with a labelled PO box: got %q, want address", got)
+	}
+	if got := p.DetectType("Mail: P.O. Box 12, Town ST 12345"); got != "po_box" {
+		t.Errorf("DetectType with a labelled PO box: got %q, want po_box", got)
+	}
+}
+
+func BenchmarkQuickClassify(b *testing.B) {
+	addr := "1005 N Gravenstein Highway, Suite 500, Sebastopol, CA 95472"
+
+	for i := 0; i < b.N; i++ {
+		QuickClassify(addr)
+	}
+}
+
+func BenchmarkDetectType(b *testing.B) {
+	p := NewParser()
+	addr := "1005 N Gravenstein Highway, Suite 500, Sebastopol, CA 95472"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.DetectType(addr)
+	}
+}
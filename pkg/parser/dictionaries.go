@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidPattern is wrapped by errors for custom patterns that do not
+// compile
+var ErrInvalidPattern = errors.New("invalid custom pattern")
+
+// Dictionaries extends the built-in word lists for one parser, for local
+// conventions the defaults do not cover
+type Dictionaries struct {
+	// StreetTypes maps extra street type abbreviations to their spelled-out
+	// names ("chs": "Chase"). Both forms are recognized as a street type and
+	// normalize to the abbreviation. Entries override built-in ones.
+	StreetTypes map[string]string
+
+	// NotePatterns are extra regular expressions for delivery notes, on top
+	// of the built-in ones ("(?i)beware of dog"). Matches are removed
+	// from the input and returned in ParseResult.Notes. Patterns that do
+	// not compile are skipped; see Validate.
+	NotePatterns []string
+}
+
+// Validate reports the custom patterns that do not compile, each wrapping
+// ErrInvalidPattern. A parser built from d skips them and keeps its
+// built-in patterns, so callers can log the error and carry on.
+func (d Dictionaries) Validate() error {
+	_, err := compilePatterns(d.NotePatterns)
+	return err
+}
+
+// compilePatterns compiles the patterns that are valid and returns the
+// errors for the rest
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	var errs []error
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w %q: %v", ErrInvalidPattern, pattern, err))
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, errors.Join(errs...)
+}
+
+// NewParserWithDictionaries creates a new address parser with optional
+// behaviour enabled and the built-in dictionaries extended by dicts
+func NewParserWithDictionaries(opts ParseOptions, dicts Dictionaries) *Parser {
+	p := &Parser{options: opts}
+	p.init()
+	for abbr, name := range dicts.StreetTypes {
+		abbr = strings.ToLower(strings.TrimSpace(abbr))
+		p.streetTypes[abbr] = abbr
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			p.streetTypes[name] = abbr
+		}
+	}
+	p.notePatterns, _ = compilePatterns(dicts.NotePatterns)
+	return p
+}
+
+// normalizeStreetType is NormalizeStreetType using the parser's street types
+func (p *Parser) normalizeStreetType(streetType string) string {
+	streetType = strings.ToLower(strings.TrimSpace(streetType))
+	if abbr, ok := p.streetTypes[streetType]; ok {
+		return abbr
+	}
+	return streetType
+}
+
+// isStreetType reports whether word is one of the parser's street types,
+// either spelled out ("Street") or already abbreviated ("St")
+func (p *Parser) isStreetType(word string) bool {
+	_, ok := p.streetTypes[strings.ToLower(strings.TrimSpace(word))]
+	return ok
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewParserWithDictionaries(t *testing.T) {
+	p := NewParserWithDictionaries(ParseOptions{}, Dictionaries{
+		StreetTypes: map[string]string{"chs": "Chase", "Cls": "Close"},
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Custom abbreviation",
+			input:    "100 Hickory Chs",
+			expected: ParsedAddress{Number: "100", Street: "Hickory", Type: "chs"},
+		},
+		{
+			name:     "Custom name normalizes to the abbreviation",
+			input:    "200 Willow Close, Franklin, TN 37064",
+			expected: ParsedAddress{Number: "200", Street: "Willow", Type: "cls", City: "Franklin", State: "TN", ZIP: "37064"},
+		},
+		{
+			name:     "Built-in types still recognized",
+			input:    "300 Main St",
+			expected: ParsedAddress{Number: "300", Street: "Main", Type: "st"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+
+	// Other parsers keep the built-in dictionary
+	if got := NewParser().ParseAddress("100 Hickory Chs"); got.Type != "" {
+		t.Errorf("Default parser: got Type %q, want none", got.Type)
+	}
+}
+
+func TestDictionariesNotePatterns(t *testing.T) {
+	dicts := Dictionaries{NotePatterns: []string{"(?i)beware of dog", "(unclosed", "[z-a]"}}
+
+	err := dicts.Validate()
+	if !errors.Is(err, ErrInvalidPattern) {
+		t.Fatalf("Validate(): got %v, want ErrInvalidPattern", err)
+	}
+	for _, pattern := range []string{"(unclosed", "[z-a]"} {
+		if !strings.Contains(err.Error(), pattern) {
+			t.Errorf("Validate(): %q not reported in %v", pattern, err)
+		}
+	}
+
+	// Bad patterns are skipped; the valid one and the built-in ones work
+	p := NewParserWithDictionaries(ParseOptions{}, dicts)
+	result, err := p.ParseLocation("123 Main St (ring bell), Beware of dog, Springfield IL 62701")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	expected := ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62701"}
+	if result.Address == nil || *result.Address != expected {
+		t.Errorf("got %+v, want %+v", result.Address, expected)
+	}
+	if result.Notes != "ring bell; Beware of dog" {
+		t.Errorf("notes: got %q, want %q", result.Notes, "ring bell; Beware of dog")
+	}
+
+	if err := (Dictionaries{NotePatterns: []string{`\bgate\b`}}).Validate(); err != nil {
+		t.Errorf("Validate() with valid patterns: got %v, want nil", err)
+	}
+}
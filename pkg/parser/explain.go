@@ -0,0 +1,50 @@
+package parser
+
+import "context"
+
+// ParseExplanation describes how ParseLocation arrived at its result, for
+// debugging a wrong parse
+type ParseExplanation struct {
+	Input  string        `json:"input" xml:"input"`
+	Steps  []ExplainStep `json:"steps" xml:"steps>step"`
+	Result *ParseResult  `json:"result,omitempty" xml:"result,omitempty"`
+	Error  string        `json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// ExplainStep is one stage of a parse
+type ExplainStep struct {
+	Stage     string `json:"stage" xml:"stage"`                             // "validate", "branch", "zip", "unit", "locality", "number", ...
+	Detail    string `json:"detail" xml:"detail"`                           // What the stage matched or decided
+	Remaining string `json:"remaining,omitempty" xml:"remaining,omitempty"` // Text left to parse after the stage
+}
+
+// Explain parses the address like ParseLocation and returns the ordered
+// steps taken: which parsers ran, what each stage of the standard parser
+// matched and removed, and how the candidates were ranked
+func (p *Parser) Explain(address string) *ParseExplanation {
+	tr := &trace{}
+	result, err := p.parseLocation(context.Background(), address, tr)
+
+	explanation := &ParseExplanation{
+		Input:  address,
+		Steps:  tr.steps,
+		Result: result,
+	}
+	if err != nil {
+		explanation.Error = err.Error()
+	}
+	return explanation
+}
+
+// trace collects ExplainSteps. A nil trace records nothing, so parsing code
+// can call add unconditionally.
+type trace struct {
+	steps []ExplainStep
+}
+
+func (t *trace) add(stage, detail, remaining string) {
+	if t == nil {
+		return
+	}
+	t.steps = append(t.steps, ExplainStep{Stage: stage, Detail: detail, Remaining: remaining})
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	p := NewParser()
+
+	explanation := p.Explain("1005 N Gravenstein Hwy, Sebastopol, CA 95472-1234")
+	if explanation.Error != "" {
+		t.Fatalf("Explain() error: %s", explanation.Error)
+	}
+	if explanation.Result == nil || explanation.Result.Address == nil {
+		t.Fatalf("Explain() result: got %+v, want an address", explanation.Result)
+	}
+
+	var zipStep *ExplainStep
+	var stages []string
+	for i, step := range explanation.Steps {
+		stages = append(stages, step.Stage)
+		if step.Stage == "zip" {
+			zipStep = &explanation.Steps[i]
+		}
+	}
+	if zipStep == nil {
+		t.Fatalf("Steps: no zip step in %v", stages)
+	}
+	if !strings.Contains(zipStep.Detail, `"95472"`) || !strings.Contains(zipStep.Detail, `"1234"`) {
+		t.Errorf("zip step detail: got %q, want the ZIP and plus4", zipStep.Detail)
+	}
+	if strings.Contains(zipStep.Remaining, "95472") {
+		t.Errorf("zip step remaining: got %q, want the ZIP removed", zipStep.Remaining)
+	}
+
+	// Stages run in pipeline order
+	want := []string{"validate", "branch", "zip", "locality", "number", "prefix", "type", "street", "rank"}
+	if strings.Join(stages, ",") != strings.Join(want, ",") {
+		t.Errorf("Stages: got %v, want %v", stages, want)
+	}
+}
+
+func TestExplainInvalidInput(t *testing.T) {
+	explanation := NewParser().Explain("123 Main\x00St")
+	if explanation.Error == "" {
+		t.Error("Explain() error: got none, want a validation error")
+	}
+	if len(explanation.Steps) != 1 || explanation.Steps[0].Stage != "validate" {
+		t.Errorf("Steps: got %+v, want a single validate step", explanation.Steps)
+	}
+}
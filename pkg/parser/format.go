@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidLayout is wrapped by errors for fixed-width layouts with a
+// column that cannot be rendered
+var ErrInvalidLayout = errors.New("invalid fixed-width layout")
+
+// FixedWidthColumn describes one column of a fixed-width record
+type FixedWidthColumn struct {
+	Field string // ParsedAddress field name, e.g. "Number" or "ZIP"
+	Width int    // Column width in runes, not bytes
+}
+
+// DefaultFixedWidthLayout is the column layout used by FormatFixedWidth.
+// The record is 102 characters wide.
+var DefaultFixedWidthLayout = []FixedWidthColumn{
+	{Field: "Number", Width: 10},
+	{Field: "Prefix", Width: 2},
+	{Field: "Street", Width: 30},
+	{Field: "Type", Width: 4},
+	{Field: "Suffix", Width: 2},
+	{Field: "SecUnitType", Width: 8},
+	{Field: "SecUnitNum", Width: 8},
+	{Field: "City", Width: 27},
+	{Field: "State", Width: 2},
+	{Field: "ZIP", Width: 5},
+	{Field: "Plus4", Width: 4},
+}
+
+// FormatFixedWidth renders p as a fixed-width record using
+// DefaultFixedWidthLayout, for systems that ingest COBOL-style records
+func FormatFixedWidth(p *ParsedAddress) string {
+	record, _ := FormatFixedWidthLayout(p, DefaultFixedWidthLayout)
+	return record
+}
+
+// FormatFixedWidthLayout renders p as a fixed-width record with the given
+// columns. Each value is space-padded on the right or truncated to fit its
+// column; unknown field names produce a blank column. Widths count runes,
+// so a record holding non-ASCII text is longer in bytes than the sum of
+// the widths. A column with a negative width gives an error wrapping
+// ErrInvalidLayout.
+func FormatFixedWidthLayout(p *ParsedAddress, layout []FixedWidthColumn) (string, error) {
+	for _, col := range layout {
+		if col.Width < 0 {
+			return "", fmt.Errorf("%w: column %q has width %d", ErrInvalidLayout, col.Field, col.Width)
+		}
+	}
+
+	var b strings.Builder
+	for _, col := range layout {
+		value := ""
+		if p != nil {
+			value = p.field(col.Field)
+		}
+		if utf8.RuneCountInString(value) > col.Width {
+			value = string([]rune(value)[:col.Width])
+		}
+		b.WriteString(value)
+		b.WriteString(strings.Repeat(" ", col.Width-utf8.RuneCountInString(value)))
+	}
+	return b.String(), nil
+}
+
+// field returns the value of the named ParsedAddress field, or "" if there
+// is no such field
+func (p *ParsedAddress) field(name string) string {
+	switch name {
+	case "Attention":
+		return p.Attention
+	case "CareOf":
+		return p.CareOf
+	case "BuildingName":
+		return p.BuildingName
+	case "Number":
+		return p.Number
+	case "NumberLow":
+		return p.NumberLow
+	case "NumberHigh":
+		return p.NumberHigh
+	case "Prefix":
+		return p.Prefix
+	case "Street":
+		return p.Street
+	case "Type":
+		return p.Type
+	case "Suffix":
+		return p.Suffix
+	case "SecUnitType":
+		return p.SecUnitType
+	case "SecUnitNum":
+		return p.SecUnitNum
+	case "SecUnitType2":
+		return p.SecUnitType2
+	case "SecUnitNum2":
+		return p.SecUnitNum2
+	case "City":
+		return p.City
+	case "County":
+		return p.County
+	case "State":
+		return p.State
+	case "ZIP":
+		return p.ZIP
+	case "Plus4":
+		return p.Plus4
+	case "Country":
+		return p.Country
+	case "Parcel":
+		return p.Parcel
+	case "RouteNumber":
+		return p.RouteNumber
+	}
+	return ""
+}
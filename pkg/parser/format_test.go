@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFormatFixedWidth(t *testing.T) {
+	addr := &ParsedAddress{
+		Number:      "1005",
+		Prefix:      "N",
+		Street:      "Gravenstein",
+		Type:        "hwy",
+		SecUnitType: "Suite",
+		SecUnitNum:  "500",
+		City:        "Sebastopol",
+		State:       "CA",
+		ZIP:         "95472",
+		Plus4:       "1234",
+	}
+
+	record := FormatFixedWidth(addr)
+	if len(record) != 102 {
+		t.Fatalf("record length: got %d, want 102", len(record))
+	}
+
+	columns := []struct {
+		field    string
+		start    int
+		end      int
+		expected string
+	}{
+		{"Number", 0, 10, "1005      "},
+		{"Prefix", 10, 12, "N "},
+		{"Street", 12, 42, "Gravenstein                   "},
+		{"Type", 42, 46, "hwy "},
+		{"Suffix", 46, 48, "  "},
+		{"SecUnitType", 48, 56, "Suite   "},
+		{"SecUnitNum", 56, 64, "500     "},
+		{"City", 64, 91, "Sebastopol                 "},
+		{"State", 91, 93, "CA"},
+		{"ZIP", 93, 98, "95472"},
+		{"Plus4", 98, 102, "1234"},
+	}
+
+	for _, col := range columns {
+		if got := record[col.start:col.end]; got != col.expected {
+			t.Errorf("%s [%d:%d]: got %q, want %q", col.field, col.start, col.end, got, col.expected)
+		}
+	}
+}
+
+func TestFormatFixedWidthLayout(t *testing.T) {
+	addr := &ParsedAddress{Number: "123", Street: "Martin Luther King Jr", State: "CA"}
+	layout := []FixedWidthColumn{
+		{Field: "Number", Width: 6},
+		{Field: "Street", Width: 10},
+		{Field: "Unknown", Width: 3},
+		{Field: "State", Width: 2},
+	}
+
+	got, err := FormatFixedWidthLayout(addr, layout)
+	want := "123   Martin Lut   CA"
+	if err != nil || got != want {
+		t.Errorf("got %q, %v, want %q", got, err, want)
+	}
+
+	if got, _ := FormatFixedWidthLayout(nil, layout); got != "                     " {
+		t.Errorf("nil address: got %q, want blank record", got)
+	}
+
+	// Widths count runes, so multi-byte text is truncated and padded by
+	// character
+	got, _ = FormatFixedWidthLayout(&ParsedAddress{City: "Montréal"}, []FixedWidthColumn{{Field: "City", Width: 10}})
+	if got != "Montréal  " {
+		t.Errorf("multi-byte city: got %q, want %q", got, "Montréal  ")
+	}
+
+	_, err = FormatFixedWidthLayout(addr, []FixedWidthColumn{{Field: "Number", Width: -1}})
+	if !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("negative width: got error %v, want ErrInvalidLayout", err)
+	}
+}
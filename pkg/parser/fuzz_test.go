@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+// FuzzParseLocation feeds arbitrary input through validation and every
+// parser entry point, checking for panics and runaway parse times. Longer
+// seeds live in testdata/fuzz/FuzzParseLocation, where crashing inputs
+// found by go test -fuzz should be added too.
+func FuzzParseLocation(f *testing.F) {
+	seeds := []string{
+		"",
+		"123 Main St, Springfield, IL 62701",
+		"1005 N Gravenstein Hwy Sebastopol CA 95472",
+		"PO Box 1234, Austin, TX 78701-1234",
+		"Mission St and Valencia St, San Francisco, CA",
+		"123 Main St Apt 4B Springfield IL",
+		"Suite 12345",
+		"NE",
+		"CA 9 5 4 7 2",
+		"Calle 5 #123 Depto 4",
+		"123 Rue Saint-Denis, Montréal, QC",
+		", , ,",
+		"# # #",
+		"123 Main\x00St",
+		"\u200b\ufeff",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	parsers := []*Parser{
+		NewParser(),
+		NewParserWithOptions(ParseOptions{
+			SpelledNumbers:   true,
+			SplitGluedTokens: true,
+			StripContacts:    true,
+			JoinSpacedZIP:    true,
+			PadZIP:           true,
+			FoldDiacritics:   true,
+			ReportPresence:   true,
+			Locale:           LocaleSpanish,
+		}),
+		NewParserWithOptions(ParseOptions{Locale: LocaleFrenchCanadian}),
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		if _, err := ValidateAndSanitize(input); err != nil {
+			return
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for _, p := range parsers {
+				if _, err := p.ParseLocation(input); err != nil {
+					continue
+				}
+				p.ParseAddress(input)
+				p.ParseIntersection(input)
+				p.ParsePoAddress(input)
+				p.BestGuess(input)
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(fuzzParseTimeout):
+			t.Fatalf("parsing %q took longer than %v", input, fuzzParseTimeout)
+		}
+	})
+}
+
+// fuzzParseTimeout bounds the time to parse one fuzz input with every parser
+const fuzzParseTimeout = 2 * time.Second
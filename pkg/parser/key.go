@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Key returns a canonical, lower-case form of the address for comparing and
+// grouping records: number, prefix, street, type, suffix, both units, city,
+// state and ZIP joined with "|". Directionals, street types, unit types and
+// states are normalized first, so "100 North Main Street Apartment 4" and
+// "100 N Main St Apt 4" share a key. Plus4, building name, attention and
+// care-of lines are left out.
+func (p *ParsedAddress) Key() string {
+	if p == nil {
+		return ""
+	}
+	parts := []string{
+		p.Number,
+		canonicalDirectional(p.Prefix),
+		p.Street,
+		NormalizeStreetType(p.Type),
+		canonicalDirectional(p.Suffix),
+		canonicalUnitType(p.SecUnitType),
+		p.SecUnitNum,
+		canonicalUnitType(p.SecUnitType2),
+		p.SecUnitNum2,
+		p.City,
+		canonicalState(p.State),
+		p.ZIP,
+	}
+	for i, part := range parts {
+		parts[i] = strings.ToLower(strings.Join(strings.Fields(part), " "))
+	}
+	return strings.Join(parts, "|")
+}
+
+// CanonicalKey is Key, named for use as a deduplication map key
+func (p *ParsedAddress) CanonicalKey() string {
+	return p.Key()
+}
+
+// Equal reports whether p and other are the same address once formatting
+// is normalized, that is whether their keys match. Two nil addresses are
+// equal.
+func (p *ParsedAddress) Equal(other *ParsedAddress) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return p.Key() == other.Key()
+}
+
+// Fingerprint returns the hex-encoded SHA-256 of Key, a fixed-length value
+// suitable as a database or cache key
+func (p *ParsedAddress) Fingerprint() string {
+	sum := sha256.Sum256([]byte(p.Key()))
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalDirectional normalizes a directional, keeping the input when it
+// is not one
+func canonicalDirectional(dir string) string {
+	if d := NormalizeDirectional(dir); d != "" {
+		return d
+	}
+	return dir
+}
+
+// unitTypeAbbreviations maps spelled-out unit types to the USPS
+// abbreviation. Spanish designators map to the English one so keys match
+// with and without LocaleSpanish.
+var unitTypeAbbreviations = map[string]string{
+	"apartment":    "apt",
+	"departamento": "apt",
+	"depto":        "apt",
+	"dpto":         "apt",
+	"piso":         "fl",
+	"local":        "ste",
+	"basement":     "bsmt",
+	"building":     "bldg",
+	"department":   "dept",
+	"floor":        "fl",
+	"hanger":       "hngr",
+	"lobby":        "lbby",
+	"lower":        "lowr",
+	"office":       "ofc",
+	"penthouse":    "ph",
+	"room":         "rm",
+	"space":        "spc",
+	"suite":        "ste",
+	"trailer":      "trlr",
+	"upper":        "uppr",
+}
+
+// canonicalUnitType abbreviates a unit type, keeping the input when it has
+// no abbreviation
+func canonicalUnitType(unit string) string {
+	if abbr, ok := unitTypeAbbreviations[strings.ToLower(unit)]; ok {
+		return abbr
+	}
+	return unit
+}
+
+// canonicalState normalizes a state, keeping the input when it is not one
+func canonicalState(state string) string {
+	if s := NormalizeState(state); s != "" {
+		return s
+	}
+	return state
+}
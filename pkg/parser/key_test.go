@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	p := NewParser()
+
+	a := p.ParseAddress("100 North Main Street, Apt 4, Springfield, Illinois 62701")
+	b := p.ParseAddress("100 n main st apt 4 springfield IL 62701")
+	if a.Key() != b.Key() {
+		t.Errorf("keys differ: %q vs %q", a.Key(), b.Key())
+	}
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("fingerprints differ for formatting variants: %s vs %s", a.Fingerprint(), b.Fingerprint())
+	}
+	if len(a.Fingerprint()) != 64 {
+		t.Errorf("fingerprint length: got %d, want 64", len(a.Fingerprint()))
+	}
+
+	c := p.ParseAddress("102 N Main St Apt 4 Springfield IL 62701")
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Errorf("different addresses share fingerprint %s", a.Fingerprint())
+	}
+}
+
+func TestCanonicalKeyAndEqual(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{"Spelled-out directional and type", "100 North Main Street, Springfield, IL 62701", "100 N Main St, Springfield, IL 62701", true},
+		{"Case and spacing", "100 N MAIN ST  SPRINGFIELD IL 62701", "100 n main st, springfield, il 62701", true},
+		{"Spelled-out unit and state", "100 Main St Apartment 4, Springfield, Illinois", "100 Main St Apt 4, Springfield, IL", true},
+		{"Suffix directional", "500 Elm Ave Northwest", "500 Elm Ave NW", true},
+		{"Different number", "100 Main St", "102 Main St", false},
+		{"Different second unit", "100 Main St Apt 4, Bldg B", "100 Main St Apt 4, Bldg C", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := p.ParseAddress(tt.a), p.ParseAddress(tt.b)
+			if got := a.CanonicalKey() == b.CanonicalKey(); got != tt.equal {
+				t.Errorf("keys %q and %q: equal %v, want %v", a.CanonicalKey(), b.CanonicalKey(), got, tt.equal)
+			}
+			if got := a.Equal(b); got != tt.equal {
+				t.Errorf("Equal() = %v, want %v", got, tt.equal)
+			}
+		})
+	}
+
+	var none *ParsedAddress
+	if !none.Equal(nil) || none.Equal(&ParsedAddress{}) {
+		t.Error("Equal() with nil: want true only for two nil addresses")
+	}
+}
@@ -0,0 +1,169 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// labelFields maps a field label, lower-cased with single spaces, to the
+// part of the address it holds. "address" is a whole address that still
+// needs parsing.
+var labelFields = map[string]string{
+	"street":      "street",
+	"address":     "address",
+	"unit":        "unit",
+	"apt":         "unit",
+	"suite":       "unit",
+	"city":        "city",
+	"town":        "city",
+	"county":      "county",
+	"state":       "state",
+	"province":    "state",
+	"zip":         "zip",
+	"zip code":    "zip",
+	"zipcode":     "zip",
+	"postal code": "zip",
+	"postcode":    "zip",
+	"country":     "country",
+}
+
+// parseLabeled reads input that names its fields inline ("Street: 123 Main
+// St City: Springfield State: IL"). Each labeled value is mapped to its
+// field directly instead of being found by the heuristics, so "City: Bel
+// Air" is never split into a street type. Text before the first label and
+// "Address:" values are parsed as usual, and labeled fields override what
+// that finds; a labeled street replaces the whole street line, units
+// included. It returns nil when the input is not labeled; see labelLocs.
+func (p *Parser) parseLabeled(address string, tr *trace) (*ParsedAddress, *ParsedAddress) {
+	locs := p.labelLocs(address)
+	if locs == nil {
+		return nil, nil
+	}
+
+	free := []string{address[:locs[0][0]]}
+	labeled := make(map[string]string)
+	for i, loc := range locs {
+		end := len(address)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		value := strings.Trim(address[loc[1]:end], " ,;")
+		if value == "" {
+			continue
+		}
+		if field := labelField(address, loc); field == "address" {
+			free = append(free, value)
+		} else {
+			labeled[field] = value
+		}
+	}
+
+	fields := make([]string, 0, len(labeled))
+	for field := range labeled {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	tr.add("labels", fmt.Sprintf("labeled fields %s", strings.Join(fields, ", ")), "")
+
+	result, raw := &ParsedAddress{}, &ParsedAddress{}
+	if text := strings.Trim(strings.Join(free, ", "), " ,;"); text != "" {
+		result, raw = p.parseAddress(text, tr)
+	}
+
+	if value, ok := labeled["street"]; ok {
+		street, streetRaw := &ParsedAddress{}, &ParsedAddress{}
+		line := p.extractUnits(value, street, tr)
+		p.parseStreetLine(line, street, streetRaw, tr)
+		setStreetLine(result, street)
+		setStreetLine(raw, streetRaw)
+	}
+	if value, ok := labeled["unit"]; ok {
+		if unit := p.parseUnitLine(value); unit != nil {
+			result.SecUnitType = unit.SecUnitType
+			result.SecUnitNum = unit.SecUnitNum
+		}
+	}
+	if value, ok := labeled["city"]; ok {
+		result.City = value
+	}
+	if value, ok := labeled["county"]; ok {
+		if matches := p.patterns.county.FindStringSubmatch(value); matches != nil {
+			value = matches[1] + matches[2]
+		}
+		result.County = value
+	}
+	if value, ok := labeled["state"]; ok {
+		result.State = NormalizeState(value)
+		if result.State == "" {
+			result.State = p.stateAbbrev(value)
+		}
+		if result.State == "" {
+			result.State = value
+		}
+		if result.State != value {
+			raw.State = value
+		}
+	}
+	if value, ok := labeled["zip"]; ok {
+		zip := &ParsedAddress{}
+		if p.extractZIP(value, zip); zip.ZIP == "" {
+			zip.ZIP = value
+		}
+		result.ZIP, result.Plus4 = zip.ZIP, zip.Plus4
+	}
+	if value, ok := labeled["country"]; ok {
+		if result.Country = NormalizeCountry(value); result.Country == "" {
+			result.Country = value
+		} else if result.Country != value {
+			raw.Country = value
+		}
+	}
+
+	result.Normalize()
+	p.foldDiacritics(result, raw)
+	return result, raw
+}
+
+// labelLocs returns the label matches in address when it is labeled input:
+// it starts with a label, or has at least two labels other than unit
+// labels. A lone label after free text ("123 Main St Apt: 5, Springfield")
+// is ordinary punctuation and gives nil.
+func (p *Parser) labelLocs(address string) [][]int {
+	locs := p.patterns.label.FindAllStringSubmatchIndex(address, -1)
+	if locs == nil || strings.TrimSpace(address[:locs[0][0]]) == "" {
+		return locs
+	}
+	fields := 0
+	for _, loc := range locs {
+		if labelField(address, loc) != "unit" {
+			fields++
+		}
+	}
+	if fields < 2 {
+		return nil
+	}
+	return locs
+}
+
+// labelField returns the field named by the label match loc in address
+func labelField(address string, loc []int) string {
+	return labelFields[strings.Join(strings.Fields(strings.ToLower(address[loc[2]:loc[3]])), " ")]
+}
+
+// setStreetLine copies the street line fields of src, which ParseStreetLine
+// fills, onto dst
+func setStreetLine(dst, src *ParsedAddress) {
+	dst.Number = src.Number
+	dst.NumberLow = src.NumberLow
+	dst.NumberHigh = src.NumberHigh
+	dst.Prefix = src.Prefix
+	dst.Street = src.Street
+	dst.Type = src.Type
+	dst.Suffix = src.Suffix
+	dst.SecUnitType = src.SecUnitType
+	dst.SecUnitNum = src.SecUnitNum
+	dst.SecUnitType2 = src.SecUnitType2
+	dst.SecUnitNum2 = src.SecUnitNum2
+	dst.RouteNumber = src.RouteNumber
+}
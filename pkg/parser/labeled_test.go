@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestParseAddressLabeled(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Fully labeled",
+			input:    "Street: 123 Main St City: Springfield State: IL Zip: 62701-1234 Country: USA",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62701", Plus4: "1234", Country: "US"},
+		},
+		{
+			name:     "Labeled values skip the heuristics",
+			input:    "Street: 100 State St, City: Bel Air, State: Maryland, Postal Code: 21014",
+			expected: ParsedAddress{Number: "100", Street: "State", Type: "st", City: "Bel Air", State: "MD", ZIP: "21014"},
+		},
+		{
+			name:     "Partially labeled",
+			input:    "123 Main St Apt 4, City: Springfield, State: IL",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "4", City: "Springfield", State: "IL"},
+		},
+		{
+			name:     "Labeled fields override the unlabeled text",
+			input:    "123 Main St, Springfield, IL 62701, Unit: 4B, City: Springfield, ZIP Code: 62704",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitNum: "4B", City: "Springfield", State: "IL", ZIP: "62704"},
+		},
+		{
+			name:     "Address label is parsed",
+			input:    "Address: 123 Main St, Springfield, IL 62701; County: Sangamon County",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", County: "Sangamon", State: "IL", ZIP: "62701"},
+		},
+		{
+			name:     "Lone unit label after free text",
+			input:    "123 Main St Apt: 5, Springfield IL 62704",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "5", City: "Springfield", State: "IL", ZIP: "62704"},
+		},
+		{
+			name:     "Suite label segment",
+			input:    "123 Main St, Suite: 200, Springfield, IL 62704",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Suite", SecUnitNum: "200", City: "Springfield", State: "IL", ZIP: "62704"},
+		},
+		{
+			name:     "Empty labels are ignored",
+			input:    "Street: 123 Main St City: State: IL",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", State: "IL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+
+	// Through ParseLocation, with the label recorded in Raw
+	result, err := p.ParseLocation("Street: 123 Main St State: Illinois")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Type != "address" || result.Address.State != "IL" || result.Raw == nil || result.Raw.State != "Illinois" {
+		t.Errorf("ParseLocation() = %+v, raw %+v", result.Address, result.Raw)
+	}
+}
+
+func TestParseLocationBareLabel(t *testing.T) {
+	result, err := NewParser().ParseLocation("Street:")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Address != nil && result.Address.Street != "" {
+		t.Errorf("got street %q, want none", result.Address.Street)
+	}
+}
@@ -1,6 +1,12 @@
 package parser
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
 
 // Directional maps directional words to their abbreviations
 var Directional = map[string]string{
@@ -218,6 +224,54 @@ var StreetType = map[string]string{
 	"wy":    "way",
 }
 
+// FrenchStreetType maps French street types, which precede the street
+// name, to their Canada Post abbreviations
+var FrenchStreetType = map[string]string{
+	"rue":    "rue",
+	"avenue": "av", "av": "av",
+	"boulevard": "boul", "boul": "boul",
+	"chemin": "ch", "ch": "ch",
+	"montée": "montée", "montee": "montée",
+	"côte": "côte", "cote": "côte",
+	"place":     "pl",
+	"route":     "rte",
+	"rang":      "rang",
+	"impasse":   "imp",
+	"promenade": "prom",
+}
+
+// ProvinceCode maps Canadian province and territory names to their
+// two-letter abbreviations
+var ProvinceCode = map[string]string{
+	"alberta":                   "AB",
+	"british columbia":          "BC",
+	"manitoba":                  "MB",
+	"new brunswick":             "NB",
+	"newfoundland and labrador": "NL",
+	"northwest territories":     "NT",
+	"nova scotia":               "NS",
+	"nunavut":                   "NU",
+	"ontario":                   "ON",
+	"prince edward island":      "PE",
+	"quebec":                    "QC",
+	"québec":                    "QC",
+	"saskatchewan":              "SK",
+	"yukon":                     "YT",
+}
+
+// CountryCode maps the country names and codes recognized at the end of an
+// address to ISO 3166-1 alpha-2 codes. "CA" is left out, as it reads as
+// California.
+var CountryCode = map[string]string{
+	"us":                       "US",
+	"usa":                      "US",
+	"united states":            "US",
+	"united states of america": "US",
+	"canada":                   "CA",
+	"mexico":                   "MX",
+	"méxico":                   "MX",
+}
+
 // StateCode maps state names to their two-letter abbreviations
 var StateCode = map[string]string{
 	"alabama":                        "AL",
@@ -281,6 +335,35 @@ var StateCode = map[string]string{
 	"wyoming":                        "WY",
 }
 
+// DirectionalStreetNames lists street names that start with a directional
+// word which is part of the name, not a prefix ("North Shore Dr"). Keys are
+// lowercase and spelled out.
+var DirectionalStreetNames = map[string]bool{
+	"north shore": true, "south shore": true, "east shore": true, "west shore": true,
+	"north park": true, "south park": true, "east park": true, "west park": true,
+	"north point": true, "south point": true, "east point": true, "west point": true,
+	"north lake": true, "south lake": true, "east lake": true, "west lake": true,
+	"north hills": true, "south hills": true, "east hills": true, "west hills": true,
+	"north ridge": true, "south ridge": true, "east ridge": true, "west ridge": true,
+	"north star": true, "south bend": true, "east end": true, "west end": true,
+	"north haven": true, "south gate": true, "east bay": true, "west bay": true,
+	"north woods": true, "south woods": true, "east gate": true, "west gate": true,
+}
+
+// spelledUnits maps spelled-out cardinals below twenty to their values
+var spelledUnits = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+	"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+}
+
+// spelledTens maps spelled-out multiples of ten to their values
+var spelledTens = map[string]int{
+	"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+}
+
 // SecondaryUnitTypes are common apartment/suite designators
 var SecondaryUnitTypes = []string{
 	"apartment", "apt",
@@ -310,6 +393,106 @@ var SecondaryUnitTypes = []string{
 	"#",
 }
 
+// UnitWords maps unit designators that take no number ("Rear", "Upper") to
+// their canonical word
+var UnitWords = map[string]string{
+	"basement":  "Basement",
+	"bsmt":      "Basement",
+	"front":     "Front",
+	"rear":      "Rear",
+	"upper":     "Upper",
+	"uppr":      "Upper",
+	"lower":     "Lower",
+	"lowr":      "Lower",
+	"side":      "Side",
+	"penthouse": "Penthouse",
+}
+
+// SpanishUnitType maps Spanish unit designators, as used in Latin American
+// addresses ("Depto 4", "Piso 2", "Local B"), to their English equivalent
+var SpanishUnitType = map[string]string{
+	"departamento": "Apt",
+	"depto":        "Apt",
+	"dpto":         "Apt",
+	"piso":         "Fl",
+	"local":        "Ste",
+}
+
+// Acronyms lists initialisms that stay upper-case when street and city names
+// are title-cased ("FDR Drive", "US Highway 101"), keyed in lower case
+var Acronyms = map[string]bool{
+	"fdr": true,
+	"jfk": true,
+	"lbj": true,
+	"mlk": true,
+	"rfk": true,
+	"us":  true,
+}
+
+// Connectors lists words that stay lower-case inside title-cased street
+// and city names ("Ponce de Leon", "Isle of Palms"), keyed in lower case.
+// The first word of a name is always capitalized.
+var Connectors = map[string]bool{
+	"de":    true,
+	"del":   true,
+	"della": true,
+	"der":   true,
+	"des":   true,
+	"du":    true,
+	"of":    true,
+	"the":   true,
+	"van":   true,
+	"von":   true,
+}
+
+// connectorArticles are lower-cased only after a connector ("De la Cruz"),
+// so names that start with them keep their capital after another word
+// ("North Las Vegas")
+var connectorArticles = map[string]bool{
+	"el":  true,
+	"la":  true,
+	"las": true,
+	"le":  true,
+	"les": true,
+	"los": true,
+}
+
+// PlacePrefixes maps the abbreviations that start many street and city
+// names ("St Louis", "Mt Vernon", "Ft Worth") to the word they stand for,
+// keyed in lower case. "St", "Mt" and "Ft" are also street types, so a city
+// written after the street line keeps one that follows the street type
+// ("123 Main St St Louis MO"). Names keep the abbreviation as written,
+// without its period.
+var PlacePrefixes = map[string]string{
+	"st": "saint",
+	"mt": "mount",
+	"ft": "fort",
+}
+
+// isPlacePrefix reports whether word is in PlacePrefixes, abbreviated or
+// spelled out, with or without a period
+func isPlacePrefix(word string) bool {
+	word = strings.ToLower(strings.TrimSuffix(word, "."))
+	for abbr, name := range PlacePrefixes {
+		if word == abbr || word == name {
+			return true
+		}
+	}
+	return false
+}
+
+// dropPlacePeriods removes the period from PlacePrefixes abbreviations in
+// a name, so "St. Louis" and "St Louis" agree
+func dropPlacePeriods(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		if trimmed := strings.TrimSuffix(word, "."); trimmed != word && PlacePrefixes[strings.ToLower(trimmed)] != "" {
+			words[i] = trimmed
+		}
+	}
+	return strings.Join(words, " ")
+}
+
 // NormalizeDirectional normalizes directional words
 func NormalizeDirectional(dir string) string {
 	dir = strings.ToLower(strings.TrimSpace(dir))
@@ -357,3 +540,113 @@ func NormalizeState(state string) string {
 	}
 	return ""
 }
+
+// isStreetType reports whether word is a built-in street type, either
+// spelled out ("Street") or already abbreviated ("St"). Parsers also accept
+// their custom types; see Parser.isStreetType.
+func isStreetType(word string) bool {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if _, ok := StreetType[word]; ok {
+		return true
+	}
+	for _, v := range StreetType {
+		if v == word {
+			return true
+		}
+	}
+	return false
+}
+
+// spelledNumber converts a leading run of spelled-out cardinal words in words
+// ("One", "Ninety-Nine", "Two Hundred Five") to digits. It returns the digits
+// and how many words were consumed, or "" and 0 if words does not start with
+// a cardinal.
+func spelledNumber(words []string) (string, int) {
+	total, group, consumed := 0, 0, 0
+	hundred := false
+
+	for _, word := range words {
+		t, g, h := total, group, hundred
+		ok := true
+		for _, piece := range strings.Split(strings.ToLower(word), "-") {
+			if n, isUnit := spelledUnits[piece]; isUnit {
+				// A unit can follow a bare ten ("twenty one") but not
+				// another unit or a teen
+				if (n < 10 && g%10 != 0) || (n >= 10 && g%100 != 0) || (g%100 >= 10 && g%100 < 20) {
+					ok = false
+					break
+				}
+				g += n
+			} else if n, isTen := spelledTens[piece]; isTen {
+				if g%100 != 0 {
+					ok = false
+					break
+				}
+				g += n
+			} else if piece == "hundred" && g > 0 && g < 10 && !h {
+				t += g * 100
+				g = 0
+				h = true
+			} else {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			break
+		}
+		total, group, hundred = t, g, h
+		consumed++
+	}
+
+	if consumed == 0 || total+group == 0 {
+		return "", 0
+	}
+	return strconv.Itoa(total + group), consumed
+}
+
+// NormalizeFrenchStreetType returns the abbreviation for a French street
+// type, or "" if the word is not one
+func NormalizeFrenchStreetType(streetType string) string {
+	return FrenchStreetType[strings.ToLower(strings.TrimSpace(streetType))]
+}
+
+// NormalizeProvince normalizes Canadian province names to two-letter codes
+func NormalizeProvince(province string) string {
+	province = strings.ToLower(strings.TrimSpace(province))
+	if code, ok := ProvinceCode[province]; ok {
+		return code
+	}
+	province = strings.ToUpper(province)
+	for _, v := range ProvinceCode {
+		if v == province {
+			return province
+		}
+	}
+	return ""
+}
+
+// NormalizeCountry returns the ISO code for a country name or code in
+// CountryCode ("U.S.A." -> "US"), or "" if it is not one
+func NormalizeCountry(country string) string {
+	country = strings.ReplaceAll(strings.ToLower(country), ".", "")
+	return CountryCode[strings.Join(strings.Fields(country), " ")]
+}
+
+// foldedLetters spells out letters that carry no combining mark to remove
+var foldedLetters = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "Æ", "AE", "œ", "oe", "Œ", "OE",
+	"ø", "o", "Ø", "O", "ł", "l", "Ł", "L", "đ", "d", "Đ", "D",
+)
+
+// FoldDiacritics strips accents and other diacritics, leaving plain ASCII
+// letters where possible ("São Paulo" -> "Sao Paulo", "Cañon" -> "Canon")
+func FoldDiacritics(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return foldedLetters.Replace(norm.NFC.String(b.String()))
+}
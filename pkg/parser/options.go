@@ -0,0 +1,89 @@
+package parser
+
+// LocaleFrenchCanadian enables French street types written before the name
+// ("Rue Saint-Denis") and Canadian province codes
+const LocaleFrenchCanadian = "fr-CA"
+
+// LocaleSpanish keeps Spanish unit designators as written ("Depto 4")
+// instead of translating them, and reads "#" after the street name as the
+// house number ("Calle 5 #123")
+const LocaleSpanish = "es"
+
+// ParseOptions enables optional parsing behaviour. The zero value gives the
+// same results as NewParser.
+type ParseOptions struct {
+	// SpelledNumbers converts spelled-out cardinals ("One", "Twenty-Two",
+	// "Three Hundred Five") to digits in the house number and unit number
+	SpelledNumbers bool
+
+	// Locale adds regional conventions on top of US parsing. Supported:
+	// LocaleFrenchCanadian, LocaleSpanish. Empty means US only.
+	Locale string
+
+	// InformalThreshold also tries the informal parser when the standard
+	// parse scores below this confidence (0-1), keeping whichever scores
+	// higher. Zero only falls back when the standard parse is empty.
+	InformalThreshold float64
+
+	// ReportPresence fills ParseResult.Presence so callers can tell a field
+	// the parser looked for but did not find from one it never attempts
+	ReportPresence bool
+
+	// SplitGluedTokens splits a street token with the type and directional
+	// run together ("MainStN" -> "Main St N"), as produced by some OCR.
+	// Pieces are found at capital letters, so the input must keep its case.
+	SplitGluedTokens bool
+
+	// QueensNumbers reads a house number with an unspaced hyphen ("37-21",
+	// "100-110") as one Queens-style number instead of a range, leaving
+	// ParsedAddress.NumberLow and NumberHigh empty. Ranges written with "to"
+	// or a spaced hyphen are still ranges.
+	QueensNumbers bool
+
+	// StripSymbols removes emoji and other non-printable runes from the
+	// input before parsing; see StripSymbols
+	StripSymbols bool
+
+	// StripContacts removes a phone number and email address pasted in with
+	// the address ("123 Main St 555-123-4567") so they are not read as the
+	// ZIP or city. They are returned in ParseResult.Phone and Email.
+	StripContacts bool
+
+	// JoinSpacedZIP recombines a ZIP spelled out as five single digits at
+	// the end of the input ("Sebastopol CA 9 5 4 7 2"), as OCR can produce.
+	// Only digits right after a state code are joined.
+	JoinSpacedZIP bool
+
+	// PadZIP restores the leading zero of a 4-digit ZIP that lost it by
+	// passing through a number column ("Town MA 1001" -> "01001"). Only
+	// digits at the end right after a state code are padded, and only when
+	// the padded ZIP belongs to that state.
+	PadZIP bool
+
+	// FoldDiacritics strips diacritics from Street and City ("Cañon City"
+	// -> "Canon City"); the original spelling is kept in ParseResult.Raw
+	FoldDiacritics bool
+
+	// AssumeState is the state every input is known to be in ("CA" or
+	// "California"). It fills a missing state, and a different state found
+	// in the input is kept but gets a warning.
+	AssumeState string
+
+	// AssumeZIPPrefix is the start every ZIP is known to share ("95" or
+	// "954"); a ZIP that does not start with it gets a warning
+	AssumeZIPPrefix string
+
+	// ReturnEmptyOnNone sets Address to an empty ParsedAddress on results of
+	// type "none" instead of leaving it nil, for clients that always read it
+	ReturnEmptyOnNone bool
+
+	// RejectEmoji fails input containing emoji with ErrContainsEmoji
+	// instead of removing them in SanitizeInput
+	RejectEmoji bool
+
+	// MaxSegments and MaxTokens reject input with more comma-separated
+	// segments or whitespace-separated tokens; see InputLimits. Zero means
+	// no limit; MaxSegments and MaxTokens are suggested values.
+	MaxSegments int
+	MaxTokens   int
+}
@@ -1,33 +1,82 @@
 package parser
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 // Parser handles address parsing operations
 type Parser struct {
-	initialized bool
-	patterns    *regexPatterns
+	initialized  bool
+	patterns     *regexPatterns
+	options      ParseOptions
+	streetTypes  map[string]string // StreetType plus custom types, see normalizeStreetType
+	notePatterns []*regexp.Regexp  // Custom delivery note patterns, see Dictionaries
+	preprocess   func(string) string
 }
 
+// AddressParser is the parsing API of Parser, for code that wants to
+// accept a fake in its tests
+type AddressParser interface {
+	ParseLocation(address string) (*ParseResult, error)
+	ParseAddress(address string) *ParsedAddress
+	ParseIntersection(address string) *ParsedIntersection
+	ParsePoAddress(address string) *ParsedAddress
+}
+
+var _ AddressParser = (*Parser)(nil)
+
 type regexPatterns struct {
 	number      *regexp.Regexp
 	street      *regexp.Regexp
 	city        *regexp.Regexp
 	state       *regexp.Regexp
 	zip         *regexp.Regexp
+	postalCode  *regexp.Regexp
 	secUnit     *regexp.Regexp
+	unitBefore  *regexp.Regexp
+	spanishUnit *regexp.Regexp
+	hashNumber  *regexp.Regexp
 	corner      *regexp.Regexp
+	cornerAlt   *regexp.Regexp
 	poBox       *regexp.Regexp
+	bareBox     *regexp.Regexp
+	delivery    *regexp.Regexp
+	barcode     *regexp.Regexp
+	spacedZIP   *regexp.Regexp
+	shortZIP    *regexp.Regexp
+	country     *regexp.Regexp
+	county      *regexp.Regexp
+	phone       *regexp.Regexp
+	email       *regexp.Regexp
+	noteParen   *regexp.Regexp
+	instruction *regexp.Regexp
+	parcel      *regexp.Regexp
+	highway     *regexp.Regexp
+	vacant      *regexp.Regexp
 	directional *regexp.Regexp
+	building    *regexp.Regexp
+	careOf      *regexp.Regexp
+	attention   *regexp.Regexp
+	ruralRoute  *regexp.Regexp
+	label       *regexp.Regexp
 }
 
 // NewParser creates a new address parser
 func NewParser() *Parser {
-	p := &Parser{}
-	p.init()
-	return p
+	return NewParserWithOptions(ParseOptions{})
+}
+
+// NewParserWithOptions creates a new address parser with optional behaviour
+// enabled
+func NewParserWithOptions(opts ParseOptions) *Parser {
+	return NewParserWithDictionaries(opts, Dictionaries{})
 }
 
 // init initializes the parser's regex patterns
@@ -36,30 +85,132 @@ func (p *Parser) init() {
 		return
 	}
 	p.initialized = true
+	p.streetTypes = make(map[string]string, 2*len(StreetType))
+	for _, abbr := range StreetType {
+		p.streetTypes[abbr] = abbr
+	}
+	for word, abbr := range StreetType {
+		p.streetTypes[word] = abbr
+	}
 
 	// Build regex patterns
 	p.patterns = &regexPatterns{
-		// Street number: digits with optional hyphen, or grid coordinates
-		number: regexp.MustCompile(`(?i)^[^\w#]*(\d+[\-]?\d*|[NSEW]\d{1,3}[NSEW]\d{1,6})\b`),
+		// Street number: digits with optional hyphen, a range ("100-200",
+		// "100 to 200", "100–200") and a fraction ("123 1/2"), or
+		// Wisconsin-style grid coordinates ("N6W23001"), after an optional
+		// "No." or "Nr." designator
+		number: regexp.MustCompile(`(?i)^[^\w#]*(?:n[or]\.?\s*)?(\d+(?:\s*[\-\x{2013}\x{2014}]\s*\d+|\s+to\s+\d+|-?\d*)(?:\s+[1-9]/\d\b)?|[NSEW]\d{1,3}[NSEW]\d{1,6})\b`),
 
 		// ZIP code: 5 digits with optional +4
 		zip: regexp.MustCompile(`(?i)\b(\d{5})(?:[-\s]?(\d{4}))?\b`),
 
+		// Canadian postal code at the end of the input: "H2Z 1A1"
+		postalCode: regexp.MustCompile(`(?i)\b([ABCEGHJ-NPRSTVXY]\d[A-Z])\s?(\d[A-Z]\d)[\s,.]*$`),
+
 		// State: 2-letter abbreviation
 		state: regexp.MustCompile(`(?i)\b([A-Z]{2})\b`),
 
 		// Secondary unit: Apt, Suite, Unit, #, etc.
-		secUnit: regexp.MustCompile(`(?i)(?:\b(apt|apartment|suite|ste|unit|#|room|rm|floor|fl|building|bldg)\W*([a-z0-9\-]+)|(\bbasement\b|\bfront\b|\brear\b))`),
+		// A number range may follow with through/thru ("Suites 100 through 110").
+		// Group 4 is a unit word that takes no number ("Rear") or a "1/2" unit.
+		// Groups 5 and 6 are a designator glued to its number ("APT4B").
+		secUnit: regexp.MustCompile(`(?i)(?:(\b(?:apt|apartment|suites?|ste|units?|rooms?|rm|floors?|fl|building|bldg)\b|#)\W*([a-z0-9\-]+)(?:\s+(?:through|thru)\s+([a-z0-9]+))?|\b(basement|bsmt|front|rear|upper|uppr|lower|lowr|side|penthouse|\d/\d)\b|\b(apt|apartment|suite|ste|unit|room|rm|floor|fl|building|bldg)(\d[a-z0-9\-]*)\b)`),
+
+		// Spanish unit designator. The number must hold a digit or be a
+		// single letter ("Depto 4B", "Local B") so street names such as
+		// "Local St" are left alone.
+		spanishUnit: regexp.MustCompile(`(?i)\b(departamento|depto|dpto|piso|local)\.?\s*(\d[a-z0-9\-]*|[a-z]\d*)\b`),
+
+		// House number written with "#" after a street name that does not
+		// start with a number ("Calle 5 #123", "Carrera 7 # 12-34")
+		hashNumber: regexp.MustCompile(`(?i)^(\s*[a-z][^#,]*?)\s*#\s*(\d+(?:-\d+)?[a-z]?)\b`),
+
+		// Unit designator ending the text before a number, which is then
+		// the unit number and not a ZIP ("Suite 12345")
+		unitBefore: regexp.MustCompile(`(?i)(?:\b(?:apt|apartment|suites?|ste|units?|rooms?|rm|floors?|fl|building|bldg)\.?|#)\s*$`),
 
 		// Intersection indicators
-		corner: regexp.MustCompile(`(?i)\b(and|at|&|@)\b`),
+		corner: regexp.MustCompile(`(?i)\b(?:and|at)\b|[&@]`),
+
+		// Weaker intersection indicators: "/" with a space on one side, so
+		// "1/2" and "c/o" are not split, and a lower-case "x" word, since
+		// "X" is usually a name or unit ("Malcolm X Blvd", "Unit X")
+		cornerAlt: regexp.MustCompile(`\s/\s*|/\s|\sx\s`),
 
-		// PO Box
-		poBox: regexp.MustCompile(`(?i)^[^\w]*p\W*(?:o|ost\s*office)?\W*box\W*(\d+)`),
+		// PO Box, anywhere in the input ("Mail: P.O. Box 12"); the leftmost
+		// match wins, so a box at the start is preferred
+		poBox: regexp.MustCompile(`(?i)\bp\W*(?:o|ost\s*office)?\W*box\W*(\d+)`),
+
+		// Rural "Box 45" without the PO, only a box when a state follows
+		bareBox: regexp.MustCompile(`(?i)^\W*box\W*(\d+)\b`),
+
+		// General Delivery, mail held at the post office
+		delivery: regexp.MustCompile(`(?i)\bgeneral\s+delivery\b`),
 
 		// Directional prefixes/suffixes
 		directional: regexp.MustCompile(`(?i)\b(north|south|east|west|northeast|northwest|southeast|southwest|n|s|e|w|ne|nw|se|sw)\.?\b`),
 
+		// Leading building or complex name set off by a dash or comma
+		// ("Sunset Apartments - 123 Main St", "The Plaza, 768 5th Ave")
+		building: regexp.MustCompile(`(?i)^([a-z][^\d,]*?)(?:\s+[-\x{2013}\x{2014}]\s+|\s*,\s*)(\d.*)$`),
+
+		// Leading attention line ("Attn: Accounts Payable", "ATTN Billing
+		// Dept"), delimited like the care-of segment
+		attention: regexp.MustCompile(`(?i)^(?:attn|attention)\b\s*:?\s*([^,\d]+?)(?:\s*,\s*(.*)|\s+(\d.*))$`),
+
+		// Leading care-of recipient ("c/o Jane Doe, 500 Main St"). Without a
+		// comma the name runs up to the house number.
+		careOf: regexp.MustCompile(`(?i)^(?:c/o|\x{2105})\s*([^,\d]+?)(?:\s*,\s*(.*)|\s+(\d.*))$`),
+
+		// Leading delivery-point barcode digits left by scanning, longer
+		// than any house number or ZIP+4
+		barcode: regexp.MustCompile(`^(\d{11,})\s+(\S.*)$`),
+
+		// Five single digits ending the input after a two-letter word, a
+		// ZIP spaced out by OCR ("CA 9 5 4 7 2")
+		spacedZIP: regexp.MustCompile(`^(.*\b(\pL{2})[.,]?)\s+(\d)\s+(\d)\s+(\d)\s+(\d)\s+(\d)$`),
+		shortZIP:  regexp.MustCompile(`^(.*\b(\pL{2})[.,]?\s+)(\d{4})((?:-\d{4})?)$`),
+
+		// Field label before its value ("Street:", "Zip Code:"), see
+		// parseLabeled. Group 1 is the label.
+		label: regexp.MustCompile(`(?i)(?:^|[\s,;])(street|address|unit|apt|suite|city|town|county|state|province|zip\s*code|zip|postal\s+code|postcode|country)\s*:`),
+
+		// County segment ("Sonoma County", "County of Sonoma")
+		county: regexp.MustCompile(`(?i)^\s*(?:county\s+of\s+(.+?)|(.+?)\s+county)\s*$`),
+
+		// Trailing country after a comma, the ZIP or a state code ("...,
+		// IL 62704, USA", "Springfield IL USA"). Group 2 is the two-letter
+		// word before it, which must be a state code in capitals so "Ciudad
+		// de Mexico" keeps its name.
+		country: regexp.MustCompile(`(?i)^(.*?(?:,|\d\s|\b(\pL{2})\s))\s*(u\.?s\.?(?:a\.?)?|united\s+states(?:\s+of\s+america)?|canada|m[eé]xico)\s*$`),
+
+		// North American phone number, optionally labelled, and email
+		// address, removed with ParseOptions.StripContacts
+		phone: regexp.MustCompile(`(?i)(?:\b(?:tel|phone|ph)\b\.?\s*:?\s*)?((?:\+?\b1[\s.\-]?)?(?:\(\d{3}\)\s*|\b\d{3}[\s.\-])\d{3}[\s.\-]\d{4})\b`),
+		email: regexp.MustCompile(`(?i)(?:\be-?mail\b\s*:?\s*)?\b([\w.+\-]+@[\w\-]+(?:\.[\w\-]+)+)\b`),
+
+		// Delivery notes: parenthesized text with a letter in it ("(ring
+		// buzzer)"; "(217)" is left for the phone pattern), and instruction
+		// phrases running to the end of their segment ("leave at door",
+		// "gate code 1234")
+		noteParen:   regexp.MustCompile(`\(([^()]*\pL[^()]*)\)`),
+		instruction: regexp.MustCompile(`(?i)(?:\s+-\s*|\b)((?:please\s+)?(?:leave\s+(?:it\s+|(?:the\s+)?packages?\s+)?(?:at|by|on|in|with|behind|inside|outside)\b|ring\s+(?:the\s+)?(?:buzzer|bell|doorbell)\b|(?:gate|door|entry|access|buzzer)\s+code\b|call\s+(?:on|upon|before|when)\b|use\s+(?:the\s+)?(?:side|back|front|rear)\s+(?:door|entrance|gate)\b|do\s+not\s+(?:leave|ring|knock)\b)[^,;()]*)`),
+
+		// Numbered highway: interstate, US route, state route or a plain
+		// highway or route ("I-80", "US Route 101", "SR-52", "Highway 12",
+		// "Route 66"), then an optional directional or exit
+		highway: regexp.MustCompile(`(?i)^(?:(u\.?s\.?)|(i|interstate)|(s\.?r\.?|state\s+(?:route|road|highway|hwy))|(highway|hwy|route|rte|rt))(?:\s*-?\s*(?:route|rte|highway|hwy))?\s*-?\s*(\d+[a-z]?)(?:\s+(.*))?$`),
+
+		// Assessor's parcel number ("Parcel 123-45-678", "APN: 0123.456")
+		parcel: regexp.MustCompile(`(?i)\b(?:parcel|apn)\b\s*(?:no\.?|number|#)?\s*:?\s*(\d[\d\-.]*\d)`),
+
+		// Land with no structure to deliver to
+		vacant: regexp.MustCompile(`(?i)\b(?:vacant|unimproved|undeveloped)\s+(?:lot|land|parcel|acreage)\b`),
+
+		// Rural route: "Rt"/"Route" is only a rural route when a box follows
+		// ("RR 2 Box 152", "Rt 9 Box 12"); otherwise it names a highway
+		ruralRoute: regexp.MustCompile(`(?i)^(?:rr|rural\s+route|rte?|route)\.?\s*(\d+)\W+box\W*(\d+)`),
+
 		// City (simple pattern - alphanumeric with spaces, commas)
 		city: regexp.MustCompile(`(?i)([a-z][a-z\s]+)`),
 
@@ -69,154 +220,961 @@ func (p *Parser) init() {
 
 // ParseLocation is the main entry point - intelligently routes to appropriate parser
 func (p *Parser) ParseLocation(address string) (*ParseResult, error) {
+	return p.ParseLocationContext(context.Background(), address)
+}
+
+// ParseLocationContext is like ParseLocation but aborts with ctx.Err() once
+// the context is cancelled or its deadline passes. The context is checked
+// between parsing stages, so a cancelled request stops doing work promptly.
+func (p *Parser) ParseLocationContext(ctx context.Context, address string) (*ParseResult, error) {
+	return p.parseLocation(ctx, address, nil)
+}
+
+// parseLocation implements ParseLocationContext, recording each stage in tr,
+// which may be nil
+func (p *Parser) parseLocation(ctx context.Context, address string, tr *trace) (*ParseResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Validate and sanitize input
-	sanitized, err := ValidateAndSanitize(address)
+	sanitized, err := p.sanitize(address)
+	if err != nil {
+		tr.add("validate", err.Error(), "")
+		return nil, err
+	}
+	tr.add("validate", "input accepted", sanitized)
+
+	candidates, err := p.rankCandidates(ctx, sanitized, tr)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return p.noneResult(), nil
+	}
+
+	best := candidates[0]
+	if len(candidates) > 1 {
+		best.RunnerUp = candidates[1]
+		if best.RunnerUp.Confidence == best.Confidence {
+			best.Warnings = append(best.Warnings, fmt.Sprintf(
+				"%s interpretation scored the same (%.2f); see runner_up", best.RunnerUp.Type, best.Confidence))
+		}
+	}
+	return best, nil
+}
+
+// ParseAs runs the parser named by parseType ("standard", "informal",
+// "intersection" or "po_box") on the address. "auto", empty and unknown
+// types fall back to ParseLocationContext. The result's DetectedType gives
+// the type ParseLocation would have returned, with a warning when the
+// result type differs.
+func (p *Parser) ParseAs(ctx context.Context, address, parseType string) (*ParseResult, error) {
+	switch parseType {
+	case "standard", "informal", "intersection", "po_box":
+	default:
+		return p.ParseLocationContext(ctx, address)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sanitized, err := p.sanitize(address)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := p.rankCandidates(ctx, sanitized, nil)
+	if err != nil {
+		return nil, err
+	}
+	detected := "none"
+	if len(candidates) > 0 {
+		detected = candidates[0].Type
+	}
+
+	sanitized, artifact := p.stripBarcode(sanitized)
+	var phone, email string
+	if p.options.StripContacts {
+		sanitized, phone, email = p.stripContacts(sanitized)
+	}
+	sanitized, notes := p.stripNotes(sanitized)
+
+	var result *ParseResult
+	switch parseType {
+	case "standard":
+		addr, raw := p.parseAddress(sanitized, nil)
+		result = &ParseResult{Type: "address", Address: addr, Raw: rawOrNil(raw, addr)}
+	case "informal":
+		result = &ParseResult{Type: "address", Address: p.ParseInformalAddress(sanitized)}
+	case "intersection":
+		result = &ParseResult{Type: "intersection", Intersection: p.ParseIntersection(sanitized)}
+	default: // "po_box"
+		addr, raw := p.parsePoAddress(sanitized)
+		result = &ParseResult{Type: "po_box", Address: addr, Raw: rawOrNil(raw, addr)}
+	}
+	result.Partial = isPartial(result)
+	result.Warnings = append(p.warnings(result), barcodeWarnings(artifact)...)
+	result.Undeliverable = p.undeliverable(sanitized, result)
+	result.Phone, result.Email = phone, email
+	result.Notes = notes
+	p.applyContext(result)
+	result.DetectedType = detected
+	if result.DetectedType != result.Type {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"input detected as %s, parsed with the requested %s parser", result.DetectedType, parseType))
+	}
+	p.setPresence(result)
+	return result, nil
+}
+
+// ParseBatch parses each address with ParseLocationContext. Addresses that
+// fail validation come back as a result of type "none" so the output lines
+// up with the input; only a cancelled context fails the whole batch.
+func (p *Parser) ParseBatch(ctx context.Context, addresses []string) ([]*ParseResult, error) {
+	results := make([]*ParseResult, len(addresses))
+	for i, address := range addresses {
+		result, err := p.ParseLocationContext(ctx, address)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			result = p.noneResult()
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// ErrInvalidPage is returned for a negative batch limit or offset
+var ErrInvalidPage = errors.New("limit and offset must not be negative")
+
+// BatchPage is one page of batch output from ParseBatchPage
+type BatchPage struct {
+	Total   int            `json:"total" xml:"total"`   // Number of addresses in the whole batch
+	Offset  int            `json:"offset" xml:"offset"` // Index of Results[0] in the batch
+	Results []*ParseResult `json:"results" xml:"results>result"`
+}
+
+// ParseBatchPage is ParseBatch for the addresses from offset on, parsing
+// at most limit of them (all remaining when limit is 0). Only the page is
+// parsed, so a large batch can be walked in slices. An offset past the end
+// gives an empty page; Total is always the full batch size.
+func (p *Parser) ParseBatchPage(ctx context.Context, addresses []string, limit, offset int) (*BatchPage, error) {
+	if limit < 0 || offset < 0 {
+		return nil, ErrInvalidPage
+	}
+	page := &BatchPage{Total: len(addresses), Offset: offset}
+	if offset >= len(addresses) {
+		page.Results = []*ParseResult{}
+		return page, nil
+	}
+	end := len(addresses)
+	if limit > 0 && limit < end-offset {
+		end = offset + limit
+	}
+	results, err := p.ParseBatch(ctx, addresses[offset:end])
 	if err != nil {
 		return nil, err
 	}
+	page.Results = results
+	return page, nil
+}
+
+// ParseMultiplePage is ParseMultiple returning one page of the addresses
+// found in text, as ParseBatchPage
+func (p *Parser) ParseMultiplePage(text string, limit, offset int) (*BatchPage, error) {
+	return p.ParseBatchPage(context.Background(), p.splitAddresses(text), limit, offset)
+}
+
+// ParseMultiple splits pasted text holding several addresses and parses
+// each one. Addresses are separated by semicolons, blank lines, or a new
+// line that starts with a house number or PO box; other lines continue the
+// address above ("1005 N Gravenstein Hwy" / "Sebastopol, CA 95472"), as
+// does a line holding only a ZIP code ("97201").
+// Chunks that fail validation come back as type "none", like ParseBatch.
+func (p *Parser) ParseMultiple(text string) []*ParseResult {
+	results, _ := p.ParseBatch(context.Background(), p.splitAddresses(text))
+	return results
+}
+
+// splitAddresses implements the ParseMultiple splitting rules, dropping
+// empty fragments
+func (p *Parser) splitAddresses(text string) []string {
+	var chunks, lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			chunks = append(chunks, strings.Join(lines, ", "))
+			lines = nil
+		}
+	}
+
+	for _, part := range strings.Split(text, ";") {
+		for _, line := range strings.Split(part, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				flush()
+				continue
+			}
+			if (p.patterns.number.MatchString(line) && !isZIPLine(line)) || p.patterns.poBox.MatchString(line) {
+				flush()
+			}
+			lines = append(lines, line)
+		}
+		flush()
+	}
+	return chunks
+}
+
+// houseNumberRange matches the separator of a house number range
+var houseNumberRange = regexp.MustCompile(`(?i)\s*(?:[\-\x{2013}\x{2014}]|\bto\b)\s*`)
+
+// parseHouseNumber cleans a matched house number, writing a range with any
+// separator ("100 to 200", "100–200") as "100-200" and grid coordinates in
+// capitals ("n6w23001" -> "N6W23001")
+func parseHouseNumber(number string) string {
+	return strings.ToUpper(houseNumberRange.ReplaceAllString(strings.TrimSpace(number), "-"))
+}
+
+// houseNumberEnds returns the low and high ends of a matched house number
+// that is a range, or "" for a single number. "to", a dash or a spaced
+// hyphen always make a range ("100 to 110", "100 - 110"). An unspaced
+// hyphen ("100-110") is a range when the second number is the larger,
+// unless ParseOptions.QueensNumbers reads it as one Queens-style number
+// ("37-21").
+func (p *Parser) houseNumberEnds(number string) (low, high string) {
+	number = strings.TrimSpace(number)
+	loc := houseNumberRange.FindStringIndex(number)
+	if loc == nil {
+		return "", ""
+	}
+	low, high = number[:loc[0]], number[loc[1]:]
+	if !isDigits(low) || !isDigits(high) || low == "" || high == "" {
+		return "", ""
+	}
+	if number[loc[0]:loc[1]] == "-" {
+		lowN, _ := strconv.Atoi(low)
+		highN, _ := strconv.Atoi(high)
+		if p.options.QueensNumbers || highN <= lowN {
+			return "", ""
+		}
+	}
+	return low, high
+}
+
+// isZIPLine reports whether line is nothing but a ZIP or ZIP+4 code
+func isZIPLine(line string) bool {
+	if len(line) == 10 && line[5] == '-' {
+		return isDigits(line[:5]) && isDigits(line[6:])
+	}
+	return len(line) == 5 && isDigits(line)
+}
+
+// BestGuess returns the single most likely interpretation of the address.
+// Candidates are ordered by confidence; ties go to, in turn:
+//
+//  1. the candidate with more components filled in
+//  2. the candidate with a ZIP code
+//  3. a street address over a PO box over an intersection
+//
+// Input that fails validation or yields nothing gives a result of type
+// "none", never nil.
+func (p *Parser) BestGuess(address string) *ParseResult {
+	sanitized, err := p.sanitize(address)
+	if err != nil {
+		return p.noneResult()
+	}
+
+	candidates, err := p.rankCandidates(context.Background(), sanitized, nil)
+	if err != nil || len(candidates) == 0 {
+		return p.noneResult()
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return betterGuess(candidates[i], candidates[j])
+	})
+	return candidates[0]
+}
+
+// ParseCandidates returns every plausible interpretation of the address,
+// one per parser that found something, each with its confidence and
+// ordered best first. It returns nil for input that fails validation.
+func (p *Parser) ParseCandidates(address string) []*ParseResult {
+	sanitized, err := p.sanitize(address)
+	if err != nil {
+		return nil
+	}
+	candidates, _ := p.rankCandidates(context.Background(), sanitized, nil)
+	return candidates
+}
+
+// noneResult returns the result for input that yields nothing, with an
+// empty Address when ParseOptions.ReturnEmptyOnNone is set
+func (p *Parser) noneResult() *ParseResult {
+	result := &ParseResult{Type: "none"}
+	if p.options.ReturnEmptyOnNone {
+		result.Address = &ParsedAddress{}
+	}
+	return result
+}
+
+// guessTypeRank orders result types for BestGuess tie-breaking
+var guessTypeRank = map[string]int{
+	"address":      3,
+	"po_box":       2,
+	"intersection": 1,
+}
+
+// betterGuess reports whether a should rank ahead of b under the BestGuess
+// tie-breaking rules
+func betterGuess(a, b *ParseResult) bool {
+	if a.Confidence != b.Confidence {
+		return a.Confidence > b.Confidence
+	}
+	if ca, cb := componentCount(a), componentCount(b); ca != cb {
+		return ca > cb
+	}
+	if za, zb := resultZIP(a) != "", resultZIP(b) != ""; za != zb {
+		return za
+	}
+	return guessTypeRank[a.Type] > guessTypeRank[b.Type]
+}
+
+// componentCount counts the non-empty fields of a result
+func componentCount(r *ParseResult) int {
+	values := addressValues(r.Address)
+	if r.Intersection != nil {
+		values = intersectionValues(r.Intersection)
+	}
+	n := 0
+	for _, v := range values {
+		if v != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// resultZIP returns the ZIP code of an address or intersection result
+func resultZIP(r *ParseResult) string {
+	switch {
+	case r.Address != nil:
+		return r.Address.ZIP
+	case r.Intersection != nil:
+		return r.Intersection.ZIP
+	}
+	return ""
+}
+
+// rankCandidates runs every parser that could apply to the sanitized input
+// and returns the non-empty results ordered by confidence, best first. Ties
+// keep the order intersection, PO box, standard, informal.
+func (p *Parser) rankCandidates(ctx context.Context, sanitized string, tr *trace) ([]*ParseResult, error) {
+	var candidates []*ParseResult
 
-	// Check for intersection
-	if p.patterns.corner.MatchString(sanitized) {
+	sanitized, artifact := p.stripBarcode(sanitized)
+	if artifact != "" {
+		tr.add("barcode", fmt.Sprintf("dropped leading digits %q", artifact), sanitized)
+	}
+	var phone, email string
+	if p.options.StripContacts {
+		sanitized, phone, email = p.stripContacts(sanitized)
+		if phone != "" || email != "" {
+			tr.add("contacts", fmt.Sprintf("removed phone %q and email %q", phone, email), sanitized)
+		}
+	}
+	sanitized, notes := p.stripNotes(sanitized)
+	if notes != "" {
+		tr.add("notes", fmt.Sprintf("removed delivery notes %q", notes), sanitized)
+	}
+
+	// Intersection
+	if p.hasCorner(sanitized) {
+		tr.add("branch", "corner marker found, trying intersection", sanitized)
 		intersection := p.ParseIntersection(sanitized)
 		if intersection != nil && intersection.Street1 != "" {
-			return &ParseResult{
+			candidates = append(candidates, &ParseResult{
 				Type:         "intersection",
 				Intersection: intersection,
-			}, nil
+			})
 		}
 	}
 
-	// Check for PO Box
-	if p.patterns.poBox.MatchString(sanitized) {
-		addr := p.ParsePoAddress(sanitized)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// PO Box
+	if p.isPoBox(sanitized) {
+		tr.add("branch", "PO box marker found, trying PO box", sanitized)
+		addr, raw := p.parsePoAddress(sanitized)
 		if addr != nil && !addr.IsEmpty() {
-			return &ParseResult{
+			candidates = append(candidates, &ParseResult{
 				Type:    "po_box",
 				Address: addr,
-			}, nil
+				Raw:     rawOrNil(raw, addr),
+			})
 		}
 	}
 
-	// Try standard address parsing
-	addr := p.ParseAddress(sanitized)
-	if addr != nil && !addr.IsEmpty() {
-		return &ParseResult{
-			Type:    "address",
-			Address: addr,
-		}, nil
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	// Fall back to informal address parsing
-	addr = p.ParseInformalAddress(sanitized)
+	// Standard address
+	tr.add("branch", "trying standard address", sanitized)
+	addr, raw := p.parseAddress(sanitized, tr)
 	if addr != nil && !addr.IsEmpty() {
-		return &ParseResult{
+		candidates = append(candidates, &ParseResult{
 			Type:    "address",
 			Address: addr,
-		}, nil
+			Raw:     rawOrNil(raw, addr),
+		})
 	}
 
-	return &ParseResult{
-		Type: "none",
-	}, nil
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Fall back to informal address parsing when the standard parse found
+	// nothing, or scored below the configured threshold
+	if addr == nil || addr.IsEmpty() || scoreAddress(addr) < p.options.InformalThreshold {
+		tr.add("branch", "standard parse was weak, trying informal", sanitized)
+		informal := p.ParseInformalAddress(sanitized)
+		if informal != nil && !informal.IsEmpty() {
+			candidates = append(candidates, &ParseResult{
+				Type:    "address",
+				Address: informal,
+			})
+		}
+	}
+
+	ranked := candidates[:0]
+	for _, c := range candidates {
+		c.Confidence = p.score(c)
+		c.Partial = isPartial(c)
+		c.Warnings = append(p.warnings(c), barcodeWarnings(artifact)...)
+		c.Undeliverable = p.undeliverable(sanitized, c)
+		c.Phone, c.Email = phone, email
+		c.Notes = notes
+		p.applyContext(c)
+		p.setPresence(c)
+		if c.Confidence > 0 {
+			ranked = append(ranked, c)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Confidence > ranked[j].Confidence
+	})
+	for _, c := range ranked {
+		tr.add("rank", fmt.Sprintf("%s candidate scored %.2f", c.Type, c.Confidence), "")
+	}
+	return ranked, nil
 }
 
-// ParseAddress parses a standard street address
-func (p *Parser) ParseAddress(address string) *ParsedAddress {
-	result := &ParsedAddress{}
+// isPartial reports whether an address result is missing its street line,
+// as for a bare ZIP ("95472") or locality ("Sebastopol, CA")
+func isPartial(r *ParseResult) bool {
+	return r.Type == "address" && r.Address != nil &&
+		!r.Address.IsEmpty() && r.Address.Street == ""
+}
 
-	// Extract ZIP code
-	if matches := p.patterns.zip.FindStringSubmatch(address); len(matches) > 0 {
-		result.ZIP = matches[1]
-		if len(matches) > 2 && matches[2] != "" {
-			result.Plus4 = matches[2]
+// SetPreprocessor installs fn to rewrite every input for domain-specific
+// cleanup, such as "Saint" to "St". It runs right after SanitizeInput, so
+// fn sees trimmed, single-spaced text, and before the optional sanitization
+// in ParseOptions (StripSymbols, JoinSpacedZIP, PadZIP) and all parsing. A nil fn
+// removes the hook. Set it before the parser is shared between goroutines.
+func (p *Parser) SetPreprocessor(fn func(string) string) {
+	p.preprocess = fn
+}
+
+// sanitize validates and sanitizes the input, applying the preprocessor
+// and the optional sanitization enabled in ParseOptions
+func (p *Parser) sanitize(address string) (string, error) {
+	if p.options.RejectEmoji && containsEmoji(address) {
+		return "", ErrContainsEmoji
+	}
+	sanitized, err := ValidateAndSanitizeLimits(address, InputLimits{
+		MaxSegments: p.options.MaxSegments,
+		MaxTokens:   p.options.MaxTokens,
+	})
+	if err != nil {
+		return "", err
+	}
+	if p.preprocess != nil {
+		if sanitized = strings.TrimSpace(p.preprocess(sanitized)); sanitized == "" {
+			return "", ErrInputEmpty
+		}
+	}
+	if p.options.StripSymbols {
+		if sanitized = StripSymbols(sanitized); sanitized == "" {
+			return "", ErrInputEmpty
 		}
-		// Remove ZIP from address for further parsing
-		address = p.patterns.zip.ReplaceAllString(address, "")
 	}
+	if p.options.JoinSpacedZIP {
+		matches := p.patterns.spacedZIP.FindStringSubmatch(sanitized)
+		if matches != nil && NormalizeState(matches[2]) != "" {
+			sanitized = matches[1] + " " + strings.Join(matches[3:], "")
+		}
+	}
+	if p.options.PadZIP {
+		matches := p.patterns.shortZIP.FindStringSubmatch(sanitized)
+		if matches != nil && ValidateStateZIP(matches[2], "0"+matches[3]) == nil {
+			sanitized = matches[1] + "0" + matches[3] + matches[4]
+		}
+	}
+	return sanitized, nil
+}
 
-	// Extract state
-	parts := strings.Split(address, ",")
-	if len(parts) >= 2 {
-		// State is usually in the last part after city
-		lastPart := strings.TrimSpace(parts[len(parts)-1])
-		if matches := p.patterns.state.FindStringSubmatch(lastPart); len(matches) > 0 {
-			result.State = NormalizeState(matches[1])
-			address = strings.TrimSuffix(address, lastPart)
-			address = strings.TrimSuffix(address, ",")
+// warnings notes tokens in an address result that could have been read
+// another way
+func (p *Parser) warnings(r *ParseResult) []string {
+	a := r.Address
+	if r.Type != "address" || a == nil {
+		return nil
+	}
+
+	var w []string
+	if a.Street == "" && a.Type != "" {
+		word := a.Type
+		if r.Raw != nil && r.Raw.Type != "" {
+			word = r.Raw.Type
 		}
+		w = append(w, fmt.Sprintf("%q treated as street type, could be street name", word))
+	}
+	if a.Type == "" && p.isStreetType(a.Street) {
+		w = append(w, fmt.Sprintf("%q treated as street name, could be street type", a.Street))
+	}
+	if a.Street == "" && a.Type == "" && a.Suffix != "" {
+		w = append(w, "no street name")
+	}
+	return w
+}
+
+// applyContext fills a missing state from ParseOptions.AssumeState and
+// warns about a state or ZIP that conflicts with the assumed region
+func (p *Parser) applyContext(r *ParseResult) {
+	var state, zip *string
+	switch {
+	case r.Address != nil:
+		state, zip = &r.Address.State, &r.Address.ZIP
+	case r.Intersection != nil:
+		state, zip = &r.Intersection.State, &r.Intersection.ZIP
+	default:
+		return
+	}
 
-		// City is usually the second-to-last part
-		if len(parts) >= 2 {
-			result.City = strings.TrimSpace(parts[len(parts)-2])
-			address = strings.Join(parts[:len(parts)-2], ",")
+	if assumed := NormalizeState(p.options.AssumeState); assumed != "" {
+		if *state == "" {
+			*state = assumed
+		} else if *state != assumed {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("state %q conflicts with assumed state %q", *state, assumed))
 		}
-	} else if len(parts) == 1 {
-		// Try to extract state from a single line
-		words := strings.Fields(address)
-		if len(words) >= 2 {
-			// Check last few words for state
-			for i := len(words) - 1; i >= 0 && i >= len(words)-3; i-- {
-				if matches := p.patterns.state.FindStringSubmatch(words[i]); len(matches) > 0 {
-					result.State = NormalizeState(matches[1])
-					// City might be the words before state
-					if i > 0 {
-						cityEnd := i
-						cityStart := cityEnd - 1
-						// Find where city starts (after street type or number)
-						for cityStart > 0 {
-							word := strings.ToLower(words[cityStart-1])
-							if _, isType := StreetType[word]; isType {
-								break
-							}
-							if p.patterns.number.MatchString(words[cityStart-1]) {
-								break
-							}
-							cityStart--
-						}
-						result.City = strings.Join(words[cityStart:cityEnd], " ")
-						// Rebuild address without city/state
-						address = strings.Join(words[:cityStart], " ")
-					}
-					break
-				}
+	}
+	if prefix := p.options.AssumeZIPPrefix; prefix != "" && *zip != "" && !strings.HasPrefix(*zip, prefix) {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("ZIP %q does not start with assumed prefix %q", *zip, prefix))
+	}
+}
+
+// undeliverable reports why a result has no mailing address: the input
+// describes vacant land, or names a parcel with no house number. It returns
+// "" for a deliverable result.
+func (p *Parser) undeliverable(sanitized string, r *ParseResult) string {
+	if r.Type != "address" || r.Address == nil {
+		return ""
+	}
+	if p.patterns.vacant.MatchString(sanitized) {
+		return "vacant land"
+	}
+	if r.Address.Parcel != "" && r.Address.Number == "" {
+		return "parcel with no house number"
+	}
+	return ""
+}
+
+// stripBarcode removes leading barcode digits (11 or more, as some scanners
+// prepend the delivery-point barcode) and returns the rest of the address
+// and the digits removed
+func (p *Parser) stripBarcode(address string) (string, string) {
+	matches := p.patterns.barcode.FindStringSubmatch(address)
+	if matches == nil {
+		return address, ""
+	}
+	return matches[2], matches[1]
+}
+
+// extractCountry sets result.Country from a trailing country name or code
+// and returns the address without it
+func (p *Parser) extractCountry(address string, result, raw *ParsedAddress) (string, bool) {
+	matches := p.patterns.country.FindStringSubmatch(address)
+	if matches == nil || matches[2] != "" && (matches[2] != strings.ToUpper(matches[2]) || p.stateAbbrev(matches[2]) == "") {
+		return address, false
+	}
+	result.Country = NormalizeCountry(matches[3])
+	if result.Country != matches[3] {
+		raw.Country = matches[3]
+	}
+	return strings.TrimRight(matches[1], ", "), true
+}
+
+// extractCounty sets result.County from a comma segment naming a county
+// ("Sonoma County", "County of Sonoma") and returns the address without it.
+// The first segment is the street line and is never a county. A county
+// between the street line and the state ("123 Main St, Sonoma County, CA")
+// leaves no city segment, so the two are joined into one line; cutting the
+// comma out would make the street line the city.
+func (p *Parser) extractCounty(address string, result *ParsedAddress) (string, bool) {
+	parts := strings.Split(address, ",")
+	for i := 1; i < len(parts); i++ {
+		if matches := p.patterns.county.FindStringSubmatch(parts[i]); matches != nil {
+			result.County = matches[1] + matches[2]
+			if i == 1 && len(parts) == 3 {
+				return parts[0] + " " + strings.TrimSpace(parts[2]), true
 			}
+			return strings.Join(append(parts[:i:i], parts[i+1:]...), ","), true
 		}
 	}
+	return address, false
+}
 
-	// Extract secondary unit (apartment, suite, etc.)
-	if matches := p.patterns.secUnit.FindStringSubmatch(address); len(matches) > 0 {
-		if matches[1] != "" {
-			result.SecUnitType = strings.TrimSpace(matches[1])
-			if len(matches) > 2 && matches[2] != "" {
-				result.SecUnitNum = strings.TrimSpace(matches[2])
+// stripContacts removes the first phone number and email address from the
+// address, returning what is left and the values removed. Segments left
+// empty are dropped so "Main St, 555-123-4567, Reno" keeps its city.
+func (p *Parser) stripContacts(address string) (rest, phone, email string) {
+	rest = address
+	if loc := p.patterns.email.FindStringSubmatchIndex(rest); loc != nil {
+		email = rest[loc[2]:loc[3]]
+		rest = rest[:loc[0]] + " " + rest[loc[1]:]
+	}
+	if loc := p.patterns.phone.FindStringSubmatchIndex(rest); loc != nil {
+		phone = rest[loc[2]:loc[3]]
+		rest = rest[:loc[0]] + " " + rest[loc[1]:]
+	}
+	if phone == "" && email == "" {
+		return address, "", ""
+	}
+	return joinSegments(rest), phone, email
+}
+
+// stripNotes removes delivery notes from the address, such as "(ring
+// buzzer)" or a trailing "leave at door", and returns them joined with
+// "; ". The notes are kept out of every field.
+func (p *Parser) stripNotes(address string) (rest, notes string) {
+	var found []string
+	rest = p.patterns.noteParen.ReplaceAllStringFunc(address, func(m string) string {
+		found = append(found, strings.TrimSpace(m[1:len(m)-1]))
+		return " "
+	})
+	locs := p.patterns.instruction.FindAllStringSubmatchIndex(rest, -1)
+	for _, loc := range locs {
+		found = append(found, strings.TrimSpace(rest[loc[2]:loc[3]]))
+	}
+	for i := len(locs) - 1; i >= 0; i-- {
+		rest = rest[:locs[i][0]] + " " + rest[locs[i][1]:]
+	}
+	for _, re := range p.notePatterns {
+		rest = re.ReplaceAllStringFunc(rest, func(m string) string {
+			if note := strings.TrimSpace(m); note != "" {
+				found = append(found, note)
 			}
-		} else if matches[3] != "" {
-			result.SecUnitType = strings.TrimSpace(matches[3])
+			return " "
+		})
+	}
+	if len(found) == 0 {
+		return address, ""
+	}
+	return joinSegments(rest), strings.Join(found, "; ")
+}
+
+// joinSegments collapses the spaces in each comma segment and drops the
+// segments left empty after text was cut out of them
+func joinSegments(address string) string {
+	var segments []string
+	for _, segment := range strings.Split(address, ",") {
+		if segment = strings.Join(strings.Fields(segment), " "); segment != "" {
+			segments = append(segments, segment)
 		}
-		address = p.patterns.secUnit.ReplaceAllString(address, " ")
 	}
+	return strings.Join(segments, ", ")
+}
+
+// barcodeWarnings notes barcode digits dropped by stripBarcode
+func barcodeWarnings(artifact string) []string {
+	if artifact == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("leading digits %q dropped as a barcode artifact", artifact)}
+}
+
+// rawOrNil drops the raw fields that normalization left unchanged in addr,
+// and the raw capture when nothing is left
+func rawOrNil(raw, addr *ParsedAddress) *ParsedAddress {
+	if raw == nil || addr == nil {
+		return nil
+	}
+	for _, f := range []struct{ raw, value *string }{
+		{&raw.Prefix, &addr.Prefix},
+		{&raw.Street, &addr.Street},
+		{&raw.Type, &addr.Type},
+		{&raw.Suffix, &addr.Suffix},
+		{&raw.City, &addr.City},
+		{&raw.State, &addr.State},
+		{&raw.Country, &addr.Country},
+	} {
+		if *f.raw == *f.value {
+			*f.raw = ""
+		}
+	}
+	if raw.IsEmpty() {
+		return nil
+	}
+	return raw
+}
+
+// ParseAddress parses a standard street address. Input already in the
+// canonical "123 Main St, City, ST 12345" shape takes a faster path with
+// the same result.
+func (p *Parser) ParseAddress(address string) *ParsedAddress {
+	address, _ = p.stripBarcode(address)
+	address, _ = p.stripNotes(address)
+	if result := p.parseCanonical(address); result != nil {
+		return result
+	}
+	result, _ := p.parseAddress(address, nil)
+	return result
+}
 
+// ParseStreetLine parses only the street line of an address, for callers
+// that already hold the city, state and ZIP in separate fields. It fills
+// the house number, directionals, street name and type, and units; nothing
+// is read as a city, state or ZIP, so "100 State St" or "15 Oak CT" keep
+// their street words.
+func (p *Parser) ParseStreetLine(line string) *ParsedAddress {
+	result := &ParsedAddress{}
+	line, _ = p.stripBarcode(line)
+	line, _ = p.stripNotes(line)
+	line = p.extractUnits(line, result, nil)
+	p.parseStreetLine(line, result, &ParsedAddress{}, nil)
+	return result
+}
+
+// ParseUSPSLines parses an address collected as USPS "Address Line 1" (the
+// delivery address) and "Address Line 2" (the secondary unit). A unit on
+// line2 ("Apt 4B", "#4B", "Rear" or a bare "4B") replaces any unit found on
+// line1; other text on line2 is ignored.
+func (p *Parser) ParseUSPSLines(line1, line2 string) *ParsedAddress {
+	result := p.ParseAddress(line1)
+
+	line2 = strings.TrimSpace(line2)
+	if line2 == "" {
+		return result
+	}
+
+	if unit := p.parseUnitLine(line2); unit != nil {
+		result.SecUnitType = unit.SecUnitType
+		result.SecUnitNum = unit.SecUnitNum
+	}
+	return result
+}
+
+// parseUnitLine reads a line holding only a secondary unit ("Apt 4B",
+// "#4B", "Rear" or a bare "4B"), returning nil when it holds none
+func (p *Parser) parseUnitLine(line string) *ParsedAddress {
+	unit := &ParsedAddress{}
+	if _, matched := p.extractUnit(line, unit); matched == "" {
+		if word, ok := UnitWords[strings.ToLower(line)]; ok {
+			unit.SecUnitType = word
+		} else if !strings.ContainsAny(line, " ,") {
+			unit.SecUnitNum = p.unitNumber(line)
+		}
+	}
+	if unit.SecUnitType == "" && unit.SecUnitNum == "" {
+		return nil
+	}
+	return unit
+}
+
+// parseAddress parses a standard street address, also returning the raw
+// substrings that were normalized into Prefix, Type, Suffix and State. Each
+// stage is recorded in tr, which may be nil.
+func (p *Parser) parseAddress(address string, tr *trace) (*ParsedAddress, *ParsedAddress) {
+	// Labeled fields ("Street: 123 Main St City: Springfield") are read
+	// as labeled
+	if result, raw := p.parseLabeled(address, tr); result != nil {
+		return result, raw
+	}
+
+	result := &ParsedAddress{}
+	raw := &ParsedAddress{}
+
+	// Extract a trailing country, which would otherwise be read as the city
+	var ok bool
+	if address, ok = p.extractCountry(address, result, raw); ok {
+		tr.add("country", fmt.Sprintf("country %q", result.Country), address)
+	}
+
+	// Extract a county segment, which would otherwise join the city
+	if address, ok = p.extractCounty(address, result); ok {
+		tr.add("county", fmt.Sprintf("county %q", result.County), address)
+	}
+
+	// Extract leading attention and care-of lines
+	if result.Attention, address, ok = leadingSegment(p.patterns.attention, address); ok {
+		tr.add("attention", fmt.Sprintf("attention %q", result.Attention), address)
+	}
+	if result.CareOf, address, ok = leadingSegment(p.patterns.careOf, address); ok {
+		tr.add("care_of", fmt.Sprintf("care of %q", result.CareOf), address)
+	}
+
+	// Extract a parcel number and drop a vacant-land description, which
+	// would otherwise be read as the house number and street
+	if loc := p.patterns.parcel.FindStringSubmatchIndex(address); loc != nil {
+		result.Parcel = address[loc[2]:loc[3]]
+		address = address[:loc[0]] + address[loc[1]:]
+		tr.add("parcel", fmt.Sprintf("parcel number %q", result.Parcel), address)
+	}
+	if loc := p.patterns.vacant.FindStringIndex(address); loc != nil {
+		address = address[:loc[0]] + address[loc[1]:]
+		tr.add("vacant", "vacant land description removed", address)
+	}
+
+	// Extract a leading building name
+	if matches := p.patterns.building.FindStringSubmatch(address); len(matches) > 0 && p.isBuildingName(matches[1]) {
+		result.BuildingName = strings.TrimSpace(matches[1])
+		address = matches[2]
+		tr.add("building", fmt.Sprintf("building name %q", result.BuildingName), address)
+	}
+
+	// Extract a rural route and box before the box number can be taken
+	// for a ZIP
+	if matches := p.patterns.ruralRoute.FindStringSubmatch(address); len(matches) > 0 {
+		result.Street = "Rural Route " + matches[1]
+		result.SecUnitType = "Box"
+		result.SecUnitNum = matches[2]
+		address = address[len(matches[0]):]
+		tr.add("rural_route", fmt.Sprintf("rural route %q, box %q", matches[1], matches[2]), address)
+	}
+
+	// Extract ZIP code
+	address = p.extractZIP(address, result)
+	if result.Plus4 != "" {
+		tr.add("zip", fmt.Sprintf("ZIP %q with plus4 %q", result.ZIP, result.Plus4), address)
+	} else if result.ZIP != "" {
+		tr.add("zip", fmt.Sprintf("ZIP %q", result.ZIP), address)
+	} else {
+		tr.add("zip", "no ZIP at the end of the address", address)
+	}
+
+	// Extract secondary unit (apartment, suite, etc.) before the locality so
+	// the unit is not mistaken for part of the city
+	address = p.extractUnits(address, result, tr)
+
+	// Extract city and state
+	address = p.extractCityState(address, result, raw)
+	if result.City == "" && result.State == "" {
+		address = p.highwayLocality(address, result, raw)
+	}
+	tr.add("locality", fmt.Sprintf("city %q, state %q", result.City, result.State), address)
+
+	// Segments between the street line and the city name a place ("123
+	// Main St, Barnes & Noble Plaza, Town ST")
+	if place, rest := p.placeSegments(address); place != "" && result.BuildingName == "" {
+		result.BuildingName = place
+		address = rest
+		tr.add("place", fmt.Sprintf("place name %q", place), address)
+	}
+
+	p.parseStreetLine(address, result, raw, tr)
+	return result, raw
+}
+
+// extractUnits takes up to two secondary units ("Apt 4, Bldg B") from the
+// address into result, after a Spanish house number that uses the same "#"
+// ("Calle 5 #123"). It returns the address without them.
+func (p *Parser) extractUnits(address string, result *ParsedAddress, tr *trace) string {
+	// Spanish addresses put the house number after the street name
+	if p.options.Locale == LocaleSpanish {
+		if loc := p.patterns.hashNumber.FindStringSubmatchIndex(address); loc != nil {
+			result.Number = address[loc[4]:loc[5]]
+			address = address[loc[2]:loc[3]] + address[loc[1]:]
+			tr.add("number", fmt.Sprintf("house number %q after the street", result.Number), address)
+		}
+	}
+
+	var unit string
+	if address, unit = p.extractUnit(address, result); unit != "" {
+		tr.add("unit", fmt.Sprintf("matched %q: type %q, number %q", unit, result.SecUnitType, result.SecUnitNum), address)
+
+		second := &ParsedAddress{}
+		if address, unit = p.extractUnit(address, second); unit != "" {
+			result.SecUnitType2 = second.SecUnitType
+			result.SecUnitNum2 = second.SecUnitNum
+			tr.add("unit", fmt.Sprintf("matched second unit %q: type %q, number %q", unit, second.SecUnitType, second.SecUnitNum), address)
+		}
+	}
+	return address
+}
+
+// parseStreetLine reads the house number, directionals, street name and
+// type from what is left of the address once the unit and locality are
+// gone, then normalizes result
+func (p *Parser) parseStreetLine(address string, result, raw *ParsedAddress, tr *trace) {
 	// Extract street number
 	if matches := p.patterns.number.FindStringSubmatch(address); len(matches) > 0 {
-		result.Number = strings.TrimSpace(matches[1])
+		result.Number = parseHouseNumber(matches[1])
+		result.NumberLow, result.NumberHigh = p.houseNumberEnds(matches[1])
 		// Replace only the first match
 		address = strings.Replace(address, matches[0], "", 1)
+		tr.add("number", fmt.Sprintf("house number %q", result.Number), address)
+	} else if p.options.SpelledNumbers {
+		words := strings.Fields(address)
+		// Leave at least one word behind for the street name
+		if number, n := spelledNumber(words); n > 0 && n < len(words) {
+			result.Number = number
+			address = strings.Join(words[n:], " ")
+			tr.add("number", fmt.Sprintf("spelled house number %q", number), address)
+		}
 	}
 
 	// Parse remaining street components
-	address = strings.TrimSpace(address)
+	address = strings.TrimSpace(strings.ReplaceAll(address, ",", " "))
 	words := strings.Fields(address)
 
 	if len(words) == 0 {
 		result.Normalize()
-		return result
+		p.foldDiacritics(result, raw)
+		return
 	}
 
-	// Check for directional prefix
-	if len(words) > 0 {
+	// A numbered highway is the whole street line ("US Route 101")
+	if route := p.parseHighway(address, result); route != "" {
+		tr.add("highway", fmt.Sprintf("route %q", route), "")
+		result.Normalize()
+		p.foldDiacritics(result, raw)
+		// Keep the designation's own casing ("SR 52")
+		result.Street = route
+		return
+	}
+
+	// Best effort for OCR output with the type and directional glued to
+	// the name ("MainStN")
+	if p.options.SplitGluedTokens {
+		last := len(words) - 1
+		if pieces := p.splitGlued(words[last]); pieces != nil {
+			tr.add("glued", fmt.Sprintf("split %q into %q", words[last], pieces), "")
+			words = append(words[:last], pieces...)
+		}
+	}
+
+	// Check for directional prefix, unless the directional is part of the
+	// street name ("North Shore Dr", "North Ave"). A lone directional
+	// ("100 NE") is left for the suffix.
+	if len(words) > 1 && !p.directionalIsName(words) {
 		if dir := NormalizeDirectional(words[0]); dir != "" {
 			result.Prefix = dir
+			raw.Prefix = words[0]
 			words = words[1:]
+			tr.add("prefix", fmt.Sprintf("directional prefix %q", dir), strings.Join(words, " "))
 		}
 	}
 
@@ -224,26 +1182,547 @@ func (p *Parser) ParseAddress(address string) *ParsedAddress {
 	if len(words) > 0 {
 		if dir := NormalizeDirectional(words[len(words)-1]); dir != "" {
 			result.Suffix = dir
+			raw.Suffix = words[len(words)-1]
 			words = words[:len(words)-1]
+			tr.add("suffix", fmt.Sprintf("directional suffix %q", dir), strings.Join(words, " "))
 		}
 	}
 
-	// Check for street type (from end)
-	if len(words) > 0 {
-		streetType := NormalizeStreetType(words[len(words)-1])
-		if streetType != "" && streetType != words[len(words)-1] {
+	// French street types come before the name ("Rue Saint-Denis")
+	if p.options.Locale == LocaleFrenchCanadian && len(words) > 1 {
+		if streetType := NormalizeFrenchStreetType(words[0]); streetType != "" {
 			result.Type = streetType
-			words = words[:len(words)-1]
+			raw.Type = words[0]
+			words = words[1:]
+			tr.add("type", fmt.Sprintf("French street type %q", streetType), strings.Join(words, " "))
 		}
 	}
 
+	// Check for street type (from end)
+	if result.Type == "" && len(words) > 0 && p.isStreetType(words[len(words)-1]) {
+		result.Type = p.normalizeStreetType(words[len(words)-1])
+		raw.Type = words[len(words)-1]
+		words = words[:len(words)-1]
+		tr.add("type", fmt.Sprintf("street type %q", result.Type), strings.Join(words, " "))
+	}
+
 	// Remaining words are the street name
 	if len(words) > 0 {
 		result.Street = strings.Join(words, " ")
+		tr.add("street", fmt.Sprintf("street name %q", result.Street), "")
 	}
 
 	result.Normalize()
-	return result
+	p.foldDiacritics(result, raw)
+}
+
+// highwayLocality takes the city and a trailing two-letter code after a
+// numbered highway into result ("123 Rt 9 Town ST"), where a code that is
+// no state keeps the street type reading from swallowing the locality. The
+// code is only taken when it is a state or written in capitals, so "Route 9
+// Service Rd" stays a street; an unknown code is kept in raw.State only. It
+// returns the address without the locality.
+func (p *Parser) highwayLocality(address string, result, raw *ParsedAddress) string {
+	address = strings.TrimSpace(address)
+	line := address
+	if loc := p.patterns.number.FindStringIndex(address); loc != nil {
+		line = strings.TrimSpace(address[loc[1]:])
+	}
+	matches := p.patterns.highway.FindStringSubmatch(line)
+	if matches == nil {
+		return address
+	}
+	rest := strings.Fields(matches[6])
+	if len(rest) < 2 {
+		return address
+	}
+	code := rest[len(rest)-1]
+	state := p.stateAbbrev(code)
+	if state == "" && (len(code) != 2 || !isLetters(code) || code != strings.ToUpper(code)) {
+		return address
+	}
+
+	result.City = strings.Join(rest[:len(rest)-1], " ")
+	result.State = state
+	if state != code {
+		raw.State = code
+	}
+	return strings.TrimSpace(address[:len(address)-len(matches[6])])
+}
+
+// parseHighway reads a street line that is a numbered highway, setting
+// Type ("hwy", or "rte" for a plain "Route 66"), RouteNumber and any
+// directional or exit in result. It returns the canonical designation
+// ("I-80", "US 101", "SR 52", "Highway 12"), or "" with result untouched
+// when the line is something else.
+func (p *Parser) parseHighway(line string, result *ParsedAddress) string {
+	matches := p.patterns.highway.FindStringSubmatch(line)
+	if matches == nil {
+		return ""
+	}
+
+	number := strings.ToUpper(matches[5])
+	route, streetType := "", "hwy"
+	switch {
+	case matches[1] != "":
+		route = "US " + number
+	case matches[2] != "":
+		route = "I-" + number
+	case matches[3] != "":
+		route = "SR " + number
+	case p.normalizeStreetType(matches[4]) == "hwy":
+		route = "Highway " + number
+	default:
+		route, streetType = "Route "+number, "rte"
+	}
+
+	var suffix, exit string
+	if rest := strings.Fields(matches[6]); len(rest) == 1 && NormalizeDirectional(rest[0]) != "" {
+		suffix = NormalizeDirectional(rest[0])
+	} else if len(rest) == 2 && strings.EqualFold(rest[0], "exit") {
+		exit = rest[1]
+	} else if len(rest) > 0 {
+		return ""
+	}
+
+	result.Street = route
+	result.Type = streetType
+	result.RouteNumber = number
+	result.Suffix = suffix
+	if exit != "" {
+		result.SecUnitType = "Exit"
+		result.SecUnitNum = exit
+	}
+	return route
+}
+
+// foldDiacritics applies ParseOptions.FoldDiacritics to Street and City,
+// recording the original spelling in raw when folding changed it
+func (p *Parser) foldDiacritics(result, raw *ParsedAddress) {
+	if !p.options.FoldDiacritics {
+		return
+	}
+	if folded := FoldDiacritics(result.Street); folded != result.Street {
+		raw.Street = result.Street
+		result.Street = folded
+	}
+	if folded := FoldDiacritics(result.City); folded != result.City {
+		raw.City = result.City
+		result.City = folded
+	}
+}
+
+// extractUnit takes the first secondary unit from the address into result.
+// It returns the address without the unit and the text that matched.
+func (p *Parser) extractUnit(address string, result *ParsedAddress) (string, string) {
+	spanish := p.patterns.spanishUnit.FindStringSubmatchIndex(address)
+	for _, loc := range p.patterns.secUnit.FindAllStringSubmatchIndex(address, -1) {
+		if spanish != nil && spanish[0] < loc[0] {
+			break
+		}
+		matches := submatches(address, loc)
+		if matches[4] != "" && !p.followsStreet(address[:loc[0]]) {
+			// A unit word before the street type names the street
+			// ("Front St", "Upper Ridge Rd")
+			continue
+		}
+		if matches[5] != "" {
+			result.SecUnitType = matches[5]
+			result.SecUnitNum = matches[6]
+		} else if matches[1] != "" {
+			result.SecUnitType = strings.TrimSpace(matches[1])
+			if matches[2] != "" {
+				result.SecUnitNum = p.unitNumber(matches[2])
+			}
+			if matches[3] != "" {
+				result.SecUnitNum += "-" + p.unitNumber(matches[3])
+			}
+		} else if word, ok := UnitWords[strings.ToLower(matches[4])]; ok {
+			result.SecUnitType = word
+		} else {
+			// "1/2" is a unit of its own
+			result.SecUnitNum = matches[4]
+		}
+		return address[:loc[0]] + " " + address[loc[1]:], matches[0]
+	}
+	if spanish != nil {
+		matches := submatches(address, spanish)
+		result.SecUnitType = matches[1]
+		if p.options.Locale != LocaleSpanish {
+			result.SecUnitType = SpanishUnitType[strings.ToLower(matches[1])]
+		}
+		result.SecUnitNum = p.unitNumber(matches[2])
+		return address[:spanish[0]] + " " + address[spanish[1]:], matches[0]
+	}
+	return address, ""
+}
+
+// extractZIP takes the ZIP code from the end of the address: the rightmost
+// 5-digit run that is not a unit number ("Suite 12345"), unless it opens the
+// line with more text after it (the house number in "12345 Main St"). With
+// the French-Canadian locale a Canadian postal code is taken as the ZIP
+// instead. It returns the address without the ZIP.
+func (p *Parser) extractZIP(address string, result *ParsedAddress) string {
+	// Taking the postal code first keeps the province before it visible
+	if p.options.Locale == LocaleFrenchCanadian {
+		if loc := p.patterns.postalCode.FindStringSubmatchIndex(address); loc != nil {
+			matches := submatches(address, loc)
+			result.ZIP = strings.ToUpper(matches[1] + " " + matches[2])
+			return address[:loc[0]]
+		}
+	}
+
+	locs := p.patterns.zip.FindAllStringSubmatchIndex(address, -1)
+	for len(locs) > 0 && p.patterns.unitBefore.MatchString(address[:locs[len(locs)-1][0]]) {
+		locs = locs[:len(locs)-1]
+	}
+	if len(locs) == 0 {
+		return address
+	}
+	loc := locs[len(locs)-1]
+	matches := submatches(address, loc)
+
+	leading := strings.Trim(address[:loc[0]], " ,") == ""
+	if leading && strings.Trim(address[loc[1]:], " ,") != "" {
+		return address
+	}
+	if !plausibleZIP(matches[1]) {
+		return address
+	}
+
+	result.ZIP = matches[1]
+	result.Plus4 = matches[2]
+	return address[:loc[0]] + address[loc[1]:]
+}
+
+// plausibleZIP rejects 5-digit runs that cannot be ZIP codes. No ZIP starts
+// with 000.
+func plausibleZIP(zip string) bool {
+	return !strings.HasPrefix(zip, "000")
+}
+
+// extractCityState pulls the city and state off the end of address into
+// result and returns what is left for street parsing. Comma-separated input
+// takes the city from the segments; single-line input walks back from the
+// state to the street type or house number. The state as written is stored
+// in raw.
+func (p *Parser) extractCityState(address string, result, raw *ParsedAddress) string {
+	var parts []string
+	for _, part := range strings.Split(address, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	if len(parts) >= 2 {
+		// State is usually the last word of the last part, possibly
+		// sharing the part with the city ("Portland OR")
+		lastWords := strings.Fields(parts[len(parts)-1])
+		if state := p.stateName(parts); state != "" {
+			// A spelled-out state ("New York") takes the whole part
+			result.State = state
+			raw.State = parts[len(parts)-1]
+			lastWords = nil
+		} else if state := p.stateAbbrev(lastWords[len(lastWords)-1]); state != "" {
+			result.State = state
+			raw.State = lastWords[len(lastWords)-1]
+			lastWords = lastWords[:len(lastWords)-1]
+		}
+
+		if result.State != "" && len(lastWords) == 0 {
+			// State stood alone, so the city is the part before it
+			result.City = parts[len(parts)-2]
+			parts = parts[:len(parts)-2]
+		} else {
+			result.City = strings.Join(lastWords, " ")
+			parts = parts[:len(parts)-1]
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	// Try to extract state from a single line
+	words := strings.Fields(address)
+	if len(words) == 1 {
+		// A lone state code ("CA") is a partial address, not a street
+		if state := p.stateAbbrev(words[0]); state != "" {
+			result.State = state
+			raw.State = words[0]
+			return ""
+		}
+	}
+	if len(words) < 2 {
+		return address
+	}
+	// The state is a code ("IL") or spelled out ("Illinois", "New
+	// Hampshire")
+	stateWords := 1
+	state := p.stateAbbrev(words[len(words)-1])
+	if state == "" {
+		state, stateWords = trailingStateName(words)
+	}
+	if state == "" {
+		return address
+	}
+	// "NE" right after the street type is the quadrant, not Nebraska
+	// ("1 First St NE")
+	if len(words[len(words)-1]) == 2 && NormalizeDirectional(state) != "" && p.isStreetType(words[len(words)-2]) {
+		return address
+	}
+
+	// City is the run of words before the state, back to the street type
+	// or house number
+	cityEnd := len(words) - stateWords
+	cityStart := cityEnd
+	for cityStart > 0 {
+		word := words[cityStart-1]
+		// "St", "Mt" or "Ft" right after the street line starts the city
+		// ("123 Main St St Louis MO")
+		if cityStart < cityEnd && cityStart >= 2 && isPlacePrefix(word) &&
+			(p.isStreetType(words[cityStart-2]) || NormalizeDirectional(words[cityStart-2]) != "") {
+			cityStart--
+			break
+		}
+		if p.isStreetType(word) || p.patterns.number.MatchString(word) {
+			break
+		}
+		// A directional right after the street type is a suffix, as is
+		// one right after the house number ("100 NE Portland OR")
+		if cityStart >= 2 && NormalizeDirectional(word) != "" &&
+			(p.isStreetType(words[cityStart-2]) || p.patterns.number.MatchString(words[cityStart-2])) {
+			break
+		}
+		cityStart--
+	}
+	// Township names often hold street type words ("Cherry Hill Township"),
+	// so let the city run back to where the street line ends
+	if isTownship(words[cityEnd-1]) {
+		if end := p.streetLineEnd(words[:cityEnd-1]); end > 0 && end < cityEnd {
+			cityStart = end
+		}
+	}
+	// A spelled-out state with no city before it is more likely the city
+	// or street name ("123 Main St Washington")
+	written := strings.Join(words[cityEnd:], " ")
+	if cityStart == cityEnd && len(written) > 2 {
+		return address
+	}
+	result.State = state
+	raw.State = written
+	result.City = strings.Join(words[cityStart:cityEnd], " ")
+	return strings.Join(words[:cityStart], " ")
+}
+
+// trailingStateName matches a state written out in full, of up to three
+// words ("District of Columbia"), at the end of words and returns its code
+// and word count. At least one word must be left before it.
+func trailingStateName(words []string) (string, int) {
+	for n := 3; n >= 1; n-- {
+		if n >= len(words) {
+			continue
+		}
+		name := strings.Join(words[len(words)-n:], " ")
+		if len(name) <= 2 {
+			continue
+		}
+		if state := NormalizeState(name); state != "" {
+			return state, n
+		}
+	}
+	return "", 0
+}
+
+// splitGlued splits a token like "MainStN" at its capital letters when the
+// pieces end in a street type, optionally followed by a directional. It
+// returns nil when the token does not look glued.
+func (p *Parser) splitGlued(token string) []string {
+	if p.isStreetType(token) {
+		return nil
+	}
+
+	var pieces []string
+	start := 0
+	for i, r := range token {
+		if i > start && unicode.IsUpper(r) {
+			pieces = append(pieces, token[start:i])
+			start = i
+		}
+	}
+	pieces = append(pieces, token[start:])
+	if len(pieces) < 2 {
+		return nil
+	}
+
+	// Peel the directional and type off the end; the rest stays one name
+	// ("MacArthurBlvd" -> "MacArthur Blvd")
+	end := len(pieces)
+	if NormalizeDirectional(pieces[end-1]) != "" {
+		end--
+	}
+	if end < 2 || !p.isStreetType(pieces[end-1]) {
+		return nil
+	}
+	name := strings.Join(pieces[:end-1], "")
+	return append([]string{name}, pieces[end-1:]...)
+}
+
+// submatches turns a FindStringSubmatchIndex result into strings, with ""
+// for groups that did not participate
+func submatches(s string, loc []int) []string {
+	out := make([]string, len(loc)/2)
+	for i := range out {
+		if loc[2*i] >= 0 {
+			out[i] = s[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return out
+}
+
+// followsStreet reports whether text ending at a unit word has already
+// finished the street: it ends in a comma, a street type or a directional
+func (p *Parser) followsStreet(before string) bool {
+	before = strings.TrimSpace(before)
+	if strings.HasSuffix(before, ",") {
+		return true
+	}
+	words := strings.Fields(before)
+	if len(words) == 0 {
+		return false
+	}
+	last := strings.TrimSuffix(words[len(words)-1], ".")
+	return p.isStreetType(last) || NormalizeDirectional(last) != ""
+}
+
+// isTownship reports whether word marks a township ("Hamilton Township",
+// "Bloomfield Twp"), which is part of the place name
+func isTownship(word string) bool {
+	switch strings.ToLower(strings.TrimSuffix(word, ".")) {
+	case "township", "twp":
+		return true
+	}
+	return false
+}
+
+// streetLineEnd returns the index just past the street line in words that
+// start with a house number: the first street type after at least one name
+// word, plus a directional suffix. It returns 0 if there is none.
+func (p *Parser) streetLineEnd(words []string) int {
+	if len(words) == 0 || !p.patterns.number.MatchString(words[0]) {
+		return 0
+	}
+	for i := 2; i < len(words); i++ {
+		if p.isStreetType(words[i]) {
+			end := i + 1
+			if end < len(words) && NormalizeDirectional(words[end]) != "" {
+				end++
+			}
+			return end
+		}
+	}
+	return 0
+}
+
+// directionalIsName reports whether a leading directional in words belongs
+// to the street name: either it forms a known compound with the next word,
+// or only a street type follows it, with or without a directional suffix
+// (the lettered "N St NW")
+func (p *Parser) directionalIsName(words []string) bool {
+	if len(words) < 2 || NormalizeDirectional(words[0]) == "" {
+		return false
+	}
+	if DirectionalStreetNames[strings.ToLower(words[0]+" "+words[1])] {
+		return true
+	}
+	rest := words[1:]
+	if len(rest) > 1 && NormalizeDirectional(rest[len(rest)-1]) != "" {
+		rest = rest[:len(rest)-1]
+	}
+	return len(rest) == 1 && p.isStreetType(rest[0])
+}
+
+// placeSegments splits comma segments that follow a street line starting
+// with a house number. It returns them as a place name, with the street line,
+// when none of them holds a digit.
+func (p *Parser) placeSegments(address string) (place, street string) {
+	parts := strings.Split(address, ",")
+	if len(parts) < 2 || !p.patterns.number.MatchString(parts[0]) {
+		return "", address
+	}
+	var names []string
+	for _, part := range parts[1:] {
+		if strings.ContainsAny(part, "0123456789") {
+			return "", address
+		}
+		if part = strings.TrimSpace(part); part != "" {
+			names = append(names, part)
+		}
+	}
+	return strings.Join(names, ", "), parts[0]
+}
+
+// leadingSegment matches a labelled leading segment such as a care-of line
+// and returns its value and the rest of the address. The pattern captures the
+// value, then the rest after a comma or the rest from the house number on.
+func leadingSegment(re *regexp.Regexp, address string) (value, rest string, ok bool) {
+	matches := re.FindStringSubmatch(address)
+	if matches == nil {
+		return "", address, false
+	}
+	return strings.TrimSpace(matches[1]), matches[2] + matches[3], true
+}
+
+// isBuildingName reports whether a leading phrase looks like a building name
+// rather than a directional ("North, 123 Main St") or a unit ("Suite A")
+func (p *Parser) isBuildingName(phrase string) bool {
+	words := strings.Fields(phrase)
+	if len(words) == 0 {
+		return false
+	}
+	if len(words) == 1 && (NormalizeDirectional(words[0]) != "" || p.isStreetType(words[0])) {
+		return false
+	}
+	first := strings.ToLower(words[0])
+	for _, unit := range SecondaryUnitTypes {
+		if first == unit {
+			return false
+		}
+	}
+	return true
+}
+
+// stateAbbrev returns the state code for a two-letter word, or "" when the
+// word is not a recognized state abbreviation
+func (p *Parser) stateAbbrev(word string) string {
+	if !p.patterns.state.MatchString(word) || len(word) != 2 {
+		return ""
+	}
+	if p.options.Locale == LocaleFrenchCanadian {
+		if province := NormalizeProvince(word); province != "" {
+			return province
+		}
+	}
+	return NormalizeState(word)
+}
+
+// stateName matches a state written out in full as the last of at least
+// three comma parts ("1 Main St, New York, New York"). With fewer parts a
+// lone name is more likely the city ("Main St, Washington").
+func (p *Parser) stateName(parts []string) string {
+	last := parts[len(parts)-1]
+	if len(parts) < 3 || len(last) <= 2 {
+		return ""
+	}
+	return NormalizeState(last)
+}
+
+// unitNumber cleans up a captured secondary unit number
+func (p *Parser) unitNumber(num string) string {
+	num = strings.TrimSpace(num)
+	if p.options.SpelledNumbers {
+		if digits, n := spelledNumber([]string{num}); n == 1 {
+			return digits
+		}
+	}
+	return num
 }
 
 // ParseInformalAddress parses informal address formats
@@ -252,8 +1731,19 @@ func (p *Parser) ParseInformalAddress(address string) *ParsedAddress {
 	// Try the standard parser first
 	result := p.ParseAddress(address)
 
-	// If we got minimal results, try to extract what we can
-	if result.Number == "" && result.Street == "" {
+	// Without a number or street type to mark where the street ends, the
+	// standard parser reads the whole line before the state as the city.
+	// Assume a one-word city and treat the words before it as the street.
+	if result.Number == "" && result.Street == "" && result.State != "" {
+		if cityWords := strings.Fields(result.City); len(cityWords) > 1 {
+			result.Street = strings.Join(cityWords[:len(cityWords)-1], " ")
+			result.City = cityWords[len(cityWords)-1]
+		}
+	}
+
+	// If we got minimal results, try to extract what we can. Labels with
+	// no values ("Street:") are not a street.
+	if result.Number == "" && result.Street == "" && p.labelLocs(address) == nil {
 		// Try to find any street-like component
 		words := strings.Fields(address)
 		if len(words) > 0 {
@@ -266,30 +1756,60 @@ func (p *Parser) ParseInformalAddress(address string) *ParsedAddress {
 
 // ParsePoAddress parses PO Box addresses
 func (p *Parser) ParsePoAddress(address string) *ParsedAddress {
+	result, _ := p.parsePoAddress(address)
+	return result
+}
+
+// isPoBox reports whether the input looks like a PO box, a bare rural box
+// or General Delivery
+func (p *Parser) isPoBox(address string) bool {
+	return p.patterns.poBox.MatchString(address) ||
+		p.patterns.bareBox.MatchString(address) ||
+		p.patterns.delivery.MatchString(address)
+}
+
+// parsePoAddress parses a PO Box address, also returning the raw state
+func (p *Parser) parsePoAddress(address string) (*ParsedAddress, *ParsedAddress) {
 	result := &ParsedAddress{}
+	raw := &ParsedAddress{}
 
-	// Extract PO Box
-	if matches := p.patterns.poBox.FindStringSubmatch(address); len(matches) > 0 {
+	// Extract PO Box. Text before it is an attention or care-of line, or
+	// a label ("Mail:") that is dropped.
+	bareBox := false
+	if loc := p.patterns.poBox.FindStringSubmatchIndex(address); loc != nil {
 		result.SecUnitType = "PO Box"
-		if len(matches) > 1 {
-			result.SecUnitNum = matches[1]
-		}
-		address = p.patterns.poBox.ReplaceAllString(address, "")
-	}
+		result.SecUnitNum = address[loc[2]:loc[3]]
+		prefix := address[:loc[0]]
+		address = address[loc[1]:]
 
-	// Extract ZIP, state, city from remaining address
-	if matches := p.patterns.zip.FindStringSubmatch(address); len(matches) > 0 {
-		result.ZIP = matches[1]
-		if len(matches) > 2 && matches[2] != "" {
-			result.Plus4 = matches[2]
-		}
-		address = p.patterns.zip.ReplaceAllString(address, "")
+		result.Attention, prefix, _ = leadingSegment(p.patterns.attention, prefix)
+		result.CareOf, _, _ = leadingSegment(p.patterns.careOf, prefix)
+	} else if matches := p.patterns.bareBox.FindStringSubmatch(address); len(matches) > 0 {
+		result.SecUnitType = "Box"
+		result.SecUnitNum = matches[1]
+		address = address[len(matches[0]):]
+		bareBox = true
+	} else if loc := p.patterns.delivery.FindStringIndex(address); loc != nil {
+		result.SecUnitType = GeneralDelivery
+		address = address[loc[1]:]
 	}
 
-	// Extract state
-	if matches := p.patterns.state.FindStringSubmatch(address); len(matches) > 0 {
-		result.State = NormalizeState(matches[1])
-		address = p.patterns.state.ReplaceAllString(address, "")
+	// Extract country, county, ZIP, state, city from remaining address
+	address, _ = p.extractCountry(address, result, raw)
+	address, _ = p.extractCounty(address, result)
+	address = p.extractZIP(address, result)
+
+	// Extract state: the rightmost two-letter state code, so a city that
+	// opens with a two-letter word ("La Jolla", "El Paso") keeps it
+	locs := p.patterns.state.FindAllStringSubmatchIndex(address, -1)
+	for i := len(locs) - 1; i >= 0; i-- {
+		code := address[locs[i][2]:locs[i][3]]
+		if state := NormalizeState(code); state != "" {
+			result.State = state
+			raw.State = code
+			address = address[:locs[i][0]] + address[locs[i][1]:]
+			break
+		}
 	}
 
 	// Remaining is likely the city
@@ -299,16 +1819,38 @@ func (p *Parser) ParsePoAddress(address string) *ParsedAddress {
 		result.City = city
 	}
 
+	// A bare box number is only a box with a state to place it
+	if bareBox && result.State == "" {
+		return &ParsedAddress{}, &ParsedAddress{}
+	}
+
 	result.Normalize()
-	return result
+	p.foldDiacritics(result, raw)
+	return result, raw
+}
+
+// hasCorner reports whether address holds an intersection marker
+func (p *Parser) hasCorner(address string) bool {
+	return p.patterns.corner.MatchString(address) || p.patterns.cornerAlt.MatchString(address)
+}
+
+// splitCorner splits address in two at its first intersection marker. The
+// cornerAlt markers are only used when there is no other marker, so
+// "Malcolm X Blvd & 125th St" splits at the "&".
+func (p *Parser) splitCorner(address string) []string {
+	if parts := p.patterns.corner.Split(address, 2); len(parts) == 2 {
+		return parts
+	}
+	return p.patterns.cornerAlt.Split(address, 2)
 }
 
-// ParseIntersection parses street intersection addresses
+// ParseIntersection parses street intersection addresses. Streets may be
+// joined by "and", "at", "&", "@", "/" or "x" ("Mission x Valencia").
 func (p *Parser) ParseIntersection(address string) *ParsedIntersection {
 	result := &ParsedIntersection{}
 
 	// Split on intersection markers
-	parts := p.patterns.corner.Split(address, 2)
+	parts := p.splitCorner(address)
 	if len(parts) != 2 {
 		return nil
 	}
@@ -327,12 +1869,10 @@ func (p *Parser) ParseIntersection(address string) *ParsedIntersection {
 			result.Suffix1 = NormalizeDirectional(words1[len(words1)-1])
 			words1 = words1[:len(words1)-1]
 		}
-		if len(words1) > 0 {
-			streetType := NormalizeStreetType(words1[len(words1)-1])
-			if streetType != "" && streetType != words1[len(words1)-1] {
-				result.Type1 = streetType
-				words1 = words1[:len(words1)-1]
-			}
+		// A lone street type word is the name ("Mission")
+		if len(words1) > 1 && p.isStreetType(words1[len(words1)-1]) {
+			result.Type1 = p.normalizeStreetType(words1[len(words1)-1])
+			words1 = words1[:len(words1)-1]
 		}
 		if len(words1) > 0 {
 			result.Street1 = strings.Join(words1, " ")
@@ -341,24 +1881,14 @@ func (p *Parser) ParseIntersection(address string) *ParsedIntersection {
 
 	// Parse second street (may contain city/state/zip)
 	// Extract city/state/zip first
-	if matches := p.patterns.zip.FindStringSubmatch(street2); len(matches) > 0 {
-		result.ZIP = matches[1]
-		street2 = p.patterns.zip.ReplaceAllString(street2, "")
-	}
-
-	streetParts := strings.Split(street2, ",")
-	if len(streetParts) > 1 {
-		// Last part might have state
-		lastPart := strings.TrimSpace(streetParts[len(streetParts)-1])
-		if matches := p.patterns.state.FindStringSubmatch(lastPart); len(matches) > 0 {
-			result.State = NormalizeState(matches[1])
-			streetParts = streetParts[:len(streetParts)-1]
-		}
-		if len(streetParts) > 1 {
-			result.City = strings.TrimSpace(streetParts[len(streetParts)-1])
-			street2 = strings.TrimSpace(streetParts[0])
-		}
-	}
+	locality := &ParsedAddress{}
+	street2, _ = p.extractCountry(street2, locality, &ParsedAddress{})
+	result.Country = locality.Country
+	street2 = p.extractZIP(street2, locality)
+	result.ZIP = locality.ZIP
+	street2 = p.extractCityState(street2, locality, &ParsedAddress{})
+	result.City = titleCase(locality.City)
+	result.State = locality.State
 
 	words2 := strings.Fields(street2)
 	if len(words2) > 0 {
@@ -370,12 +1900,10 @@ func (p *Parser) ParseIntersection(address string) *ParsedIntersection {
 			result.Suffix2 = NormalizeDirectional(words2[len(words2)-1])
 			words2 = words2[:len(words2)-1]
 		}
-		if len(words2) > 0 {
-			streetType := NormalizeStreetType(words2[len(words2)-1])
-			if streetType != "" && streetType != words2[len(words2)-1] {
-				result.Type2 = streetType
-				words2 = words2[:len(words2)-1]
-			}
+		// A lone street type word is the name ("Mission")
+		if len(words2) > 1 && p.isStreetType(words2[len(words2)-1]) {
+			result.Type2 = p.normalizeStreetType(words2[len(words2)-1])
+			words2 = words2[:len(words2)-1]
 		}
 		if len(words2) > 0 {
 			result.Street2 = strings.Join(words2, " ")
@@ -1,7 +1,11 @@
 package parser
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseAddress(t *testing.T) {
@@ -91,7 +95,7 @@ func TestParseAddress(t *testing.T) {
 				Type:        "st",
 				SecUnitType: "Apt",
 				SecUnitNum:  "4B",
-				City:        "San",
+				City:        "San Francisco",
 				State:       "CA",
 				ZIP:         "94105",
 			},
@@ -142,6 +146,9 @@ func TestParseAddress(t *testing.T) {
 			if tt.expected.State != "" && result.State != tt.expected.State {
 				t.Errorf("State: got %q, want %q", result.State, tt.expected.State)
 			}
+			if tt.expected.City != "" && result.City != tt.expected.City {
+				t.Errorf("City: got %q, want %q", result.City, tt.expected.City)
+			}
 		})
 	}
 }
@@ -186,6 +193,44 @@ func TestParseIntersection(t *testing.T) {
 				Type2:   "st",
 			},
 		},
+		{
+			name:  "Intersection with slash",
+			input: "Mission St / Valencia St",
+			expected: ParsedIntersection{
+				Street1: "Mission",
+				Type1:   "st",
+				Street2: "Valencia",
+				Type2:   "st",
+			},
+		},
+		{
+			name:  "Intersection with x",
+			input: "Mission x Valencia",
+			expected: ParsedIntersection{
+				Street1: "Mission",
+				Street2: "Valencia",
+			},
+		},
+		{
+			name:  "Upper-case X is part of a name",
+			input: "Malcolm X Blvd / 125th St",
+			expected: ParsedIntersection{
+				Street1: "Malcolm X",
+				Type1:   "blvd",
+				Street2: "125th",
+				Type2:   "st",
+			},
+		},
+		{
+			name:  "And wins over x",
+			input: "Lot x Rd and Main St",
+			expected: ParsedIntersection{
+				Street1: "Lot x",
+				Type1:   "rd",
+				Street2: "Main",
+				Type2:   "st",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -212,6 +257,35 @@ func TestParseIntersection(t *testing.T) {
 	}
 }
 
+func TestParseLocationIntersectionSeparators(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		input    string
+		wantType string
+	}{
+		{"Mission St / Valencia St", "intersection"},
+		{"Mission x Valencia", "intersection"},
+		{"Mission St x Valencia St, San Francisco, CA", "intersection"},
+		{"123 1/2 Main St", "address"},
+		{"100 Malcolm X Blvd", "address"},
+		{"Unit X, 123 Main St", "address"},
+		{"123 Main St / Apt 4, Springfield, IL", "address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			if result.Type != tt.wantType {
+				t.Errorf("got type %q, want %q", result.Type, tt.wantType)
+			}
+		})
+	}
+}
+
 func TestParsePoAddress(t *testing.T) {
 	p := NewParser()
 
@@ -239,6 +313,25 @@ func TestParsePoAddress(t *testing.T) {
 				ZIP:         "10001",
 			},
 		},
+		{
+			name:  "Labelled PO Box",
+			input: "Mail: P.O. Box 12, Town ST 12345",
+			expected: ParsedAddress{
+				SecUnitType: "PO Box",
+				SecUnitNum:  "12",
+				ZIP:         "12345",
+			},
+		},
+		{
+			name:  "Care-of line before the box",
+			input: "c/o Jane Doe, PO Box 12, Denver CO 80201",
+			expected: ParsedAddress{
+				CareOf:      "Jane Doe",
+				SecUnitType: "PO Box",
+				SecUnitNum:  "12",
+				ZIP:         "80201",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -254,6 +347,9 @@ func TestParsePoAddress(t *testing.T) {
 			if tt.expected.ZIP != "" && result.ZIP != tt.expected.ZIP {
 				t.Errorf("ZIP: got %q, want %q", result.ZIP, tt.expected.ZIP)
 			}
+			if result.CareOf != tt.expected.CareOf {
+				t.Errorf("CareOf: got %q, want %q", result.CareOf, tt.expected.CareOf)
+			}
 		})
 	}
 }
@@ -297,6 +393,209 @@ func TestParseLocation(t *testing.T) {
 	}
 }
 
+func TestParseLocationScoring(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name           string
+		input          string
+		expectedType   string
+		expectedRunner string
+	}{
+		{
+			name:           "Street name containing a corner word is an intersection",
+			input:          "Boston Post Road and Elm St",
+			expectedType:   "intersection",
+			expectedRunner: "address",
+		},
+		{
+			name:           "Numbered address with 'and' in the street name",
+			input:          "10 Bread and Butter Ln, Springfield IL 62704",
+			expectedType:   "address",
+			expectedRunner: "intersection",
+		},
+		{
+			name:           "Numbered address with 'at' in the street name",
+			input:          "123 Art at the Park Rd",
+			expectedType:   "address",
+			expectedRunner: "intersection",
+		},
+		{
+			name:           "Intersection with ampersand",
+			input:          "5th Ave & Main St",
+			expectedType:   "intersection",
+			expectedRunner: "address",
+		},
+		{
+			name:           "Misroute: business name with an ampersand",
+			input:          "Barnes & Noble, 123 Main St, Springfield IL 62704",
+			expectedType:   "address",
+			expectedRunner: "",
+		},
+		{
+			name:           "Misroute: business name with 'and'",
+			input:          "Art and Frame Shop, 123 Main St",
+			expectedType:   "address",
+			expectedRunner: "",
+		},
+		{
+			name:         "Unambiguous address has no runner-up",
+			input:        "1005 N Gravenstein Hwy Sebastopol CA 95472",
+			expectedType: "address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation failed: %v", err)
+			}
+			if result.Type != tt.expectedType {
+				t.Errorf("Type: got %q, want %q", result.Type, tt.expectedType)
+			}
+			if result.Confidence <= 0 || result.Confidence > 1 {
+				t.Errorf("Confidence out of range: %v", result.Confidence)
+			}
+
+			if tt.expectedRunner == "" {
+				if result.RunnerUp != nil {
+					t.Errorf("RunnerUp: got %q, want none", result.RunnerUp.Type)
+				}
+				return
+			}
+			if result.RunnerUp == nil {
+				t.Fatalf("RunnerUp: got none, want %q", tt.expectedRunner)
+			}
+			if result.RunnerUp.Type != tt.expectedRunner {
+				t.Errorf("RunnerUp.Type: got %q, want %q", result.RunnerUp.Type, tt.expectedRunner)
+			}
+			if result.RunnerUp.Confidence > result.Confidence {
+				t.Errorf("RunnerUp confidence %v exceeds best %v", result.RunnerUp.Confidence, result.Confidence)
+			}
+		})
+	}
+}
+
+func TestInformalThreshold(t *testing.T) {
+	input := "Gravenstein Sebastopol CA"
+
+	t.Run("Default keeps the weak standard parse", func(t *testing.T) {
+		result, err := NewParser().ParseLocation(input)
+		if err != nil {
+			t.Fatalf("ParseLocation failed: %v", err)
+		}
+		if result.Address.Street != "" || result.Address.City != "Gravenstein Sebastopol" {
+			t.Errorf("got Street %q City %q, want the standard parse", result.Address.Street, result.Address.City)
+		}
+	})
+
+	t.Run("Threshold upgrades to the informal parse", func(t *testing.T) {
+		p := NewParserWithOptions(ParseOptions{InformalThreshold: 0.5})
+		result, err := p.ParseLocation(input)
+		if err != nil {
+			t.Fatalf("ParseLocation failed: %v", err)
+		}
+		if result.Address.Street != "Gravenstein" {
+			t.Errorf("Street: got %q, want %q", result.Address.Street, "Gravenstein")
+		}
+		if result.Address.City != "Sebastopol" {
+			t.Errorf("City: got %q, want %q", result.Address.City, "Sebastopol")
+		}
+		if result.RunnerUp == nil || result.RunnerUp.Confidence >= result.Confidence {
+			t.Errorf("expected the standard parse as a lower-scoring runner-up, got %+v", result.RunnerUp)
+		}
+	})
+
+	t.Run("Strong standard parse skips the fallback", func(t *testing.T) {
+		p := NewParserWithOptions(ParseOptions{InformalThreshold: 0.5})
+		result, err := p.ParseLocation("1005 N Gravenstein Hwy Sebastopol CA 95472")
+		if err != nil {
+			t.Fatalf("ParseLocation failed: %v", err)
+		}
+		if result.RunnerUp != nil {
+			t.Errorf("RunnerUp: got %+v, want none", result.RunnerUp)
+		}
+	})
+}
+
+func TestParseLocationRawValues(t *testing.T) {
+	p := NewParser()
+
+	result, err := p.ParseLocation("1005 North Gravenstein Highway South, Sebastopol, ca 95472")
+	if err != nil {
+		t.Fatalf("ParseLocation failed: %v", err)
+	}
+	if result.Raw == nil {
+		t.Fatal("Raw: got nil")
+	}
+
+	fields := []struct {
+		name       string
+		raw        string
+		normalized string
+		wantRaw    string
+		wantNorm   string
+	}{
+		{"Prefix", result.Raw.Prefix, result.Address.Prefix, "North", "N"},
+		{"Type", result.Raw.Type, result.Address.Type, "Highway", "hwy"},
+		{"Suffix", result.Raw.Suffix, result.Address.Suffix, "South", "S"},
+		{"State", result.Raw.State, result.Address.State, "ca", "CA"},
+	}
+	for _, f := range fields {
+		if f.raw != f.wantRaw {
+			t.Errorf("Raw.%s: got %q, want %q", f.name, f.raw, f.wantRaw)
+		}
+		if f.normalized != f.wantNorm {
+			t.Errorf("Address.%s: got %q, want %q", f.name, f.normalized, f.wantNorm)
+		}
+	}
+
+	if result.Raw.Number != "" || result.Raw.Street != "" {
+		t.Errorf("Raw should only hold normalized fields, got %+v", *result.Raw)
+	}
+
+	// Fields already in canonical form are left out of Raw
+	result, err = p.ParseLocation("123 Main St, Reno NV 89501")
+	if err != nil {
+		t.Fatalf("ParseLocation failed: %v", err)
+	}
+	if result.Raw == nil || result.Raw.State != "" || result.Raw.Type != "St" {
+		t.Errorf("Raw: got %+v, want only Type \"St\"", result.Raw)
+	}
+}
+
+// fakeParser stands in for Parser the way a consumer's test would
+type fakeParser struct{ result *ParseResult }
+
+func (f fakeParser) ParseLocation(string) (*ParseResult, error)   { return f.result, nil }
+func (f fakeParser) ParseAddress(string) *ParsedAddress           { return f.result.Address }
+func (f fakeParser) ParseIntersection(string) *ParsedIntersection { return f.result.Intersection }
+func (f fakeParser) ParsePoAddress(string) *ParsedAddress         { return f.result.Address }
+
+func TestAddressParser(t *testing.T) {
+	fake := &ParseResult{Type: "address", Address: &ParsedAddress{Street: "Fake"}}
+	parsers := map[string]AddressParser{
+		"Parser": NewParser(),
+		"fake":   fakeParser{fake},
+	}
+
+	for name, ap := range parsers {
+		t.Run(name, func(t *testing.T) {
+			result, err := ap.ParseLocation("123 Main St")
+			if err != nil {
+				t.Fatalf("ParseLocation() error = %v", err)
+			}
+			if result.Type != "address" || result.Address == nil || result.Address.Street == "" {
+				t.Errorf("got %+v, want an address with a street", result)
+			}
+			if addr := ap.ParseAddress("123 Main St"); addr == nil || addr.Street == "" {
+				t.Errorf("ParseAddress() = %+v, want a street", addr)
+			}
+		})
+	}
+}
+
 func TestNormalizers(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -312,6 +611,13 @@ func TestNormalizers(t *testing.T) {
 		{"State California", NormalizeState, "california", "CA"},
 		{"State CA", NormalizeState, "CA", "CA"},
 		{"State Texas", NormalizeState, "texas", "TX"},
+		{"Spelled one", func(s string) string { n, _ := spelledNumber([]string{s}); return n }, "One", "1"},
+		{"Spelled ninety-nine", func(s string) string { n, _ := spelledNumber([]string{s}); return n }, "Ninety-Nine", "99"},
+		{"Spelled non-number", func(s string) string { n, _ := spelledNumber([]string{s}); return n }, "Main", ""},
+		{"Fold São Paulo", FoldDiacritics, "São Paulo", "Sao Paulo"},
+		{"Fold Cañon City", FoldDiacritics, "Cañon City", "Canon City"},
+		{"Fold Straße", FoldDiacritics, "Straße", "Strasse"},
+		{"Fold plain ASCII", FoldDiacritics, "Main St", "Main St"},
 	}
 
 	for _, tt := range tests {
@@ -324,22 +630,2838 @@ func TestNormalizers(t *testing.T) {
 	}
 }
 
-func BenchmarkParseAddress(b *testing.B) {
+func TestParsedAddressMerge(t *testing.T) {
 	p := NewParser()
-	addr := "1005 N Gravenstein Highway, Suite 500, Sebastopol, CA 95472"
+	street := p.ParseStreetLine("123 N Main St Apt 4")
+	locality := p.ParseAddress("Springfield, IL 62701-1234")
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		p.ParseAddress(addr)
+	merged := street.Merge(locality)
+	expected := ParsedAddress{Number: "123", Prefix: "N", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "4", City: "Springfield", State: "IL", ZIP: "62701", Plus4: "1234"}
+	if *merged != expected {
+		t.Errorf("got %+v, want %+v", *merged, expected)
+	}
+	if street.City != "" || locality.Street != "" {
+		t.Errorf("Merge modified its inputs: %+v, %+v", *street, *locality)
+	}
+
+	tests := []struct {
+		name     string
+		a, b     ParsedAddress
+		expected ParsedAddress
+	}{
+		{
+			name:     "Receiver wins a conflict",
+			a:        ParsedAddress{City: "Springfield", State: "IL"},
+			b:        ParsedAddress{City: "Chicago", ZIP: "60601"},
+			expected: ParsedAddress{City: "Springfield", State: "IL", ZIP: "60601"},
+		},
+		{
+			name:     "Plus4 of a different ZIP is dropped",
+			a:        ParsedAddress{ZIP: "62701"},
+			b:        ParsedAddress{ZIP: "62704", Plus4: "1234"},
+			expected: ParsedAddress{ZIP: "62701"},
+		},
+		{
+			name:     "Plus4 of the same ZIP is kept",
+			a:        ParsedAddress{ZIP: "62701"},
+			b:        ParsedAddress{ZIP: "62701", Plus4: "1234"},
+			expected: ParsedAddress{ZIP: "62701", Plus4: "1234"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Merge(&tt.b); *got != tt.expected {
+				t.Errorf("got %+v, want %+v", *got, tt.expected)
+			}
+		})
+	}
+
+	if got := street.Merge(nil); got == street || *got != *street {
+		t.Errorf("Merge(nil): got %+v, want a copy of %+v", got, *street)
 	}
 }
 
-func BenchmarkParseLocation(b *testing.B) {
+func TestNormalizeTrimsPunctuation(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    ParsedAddress
+		expected ParsedAddress
+	}{
+		{
+			name:     "Trailing period on street",
+			input:    ParsedAddress{Street: "Main St."},
+			expected: ParsedAddress{Street: "Main St"},
+		},
+		{
+			name:     "Period and comma on type",
+			input:    ParsedAddress{Street: "Oak", Type: "Ave.,"},
+			expected: ParsedAddress{Street: "Oak", Type: "Ave"},
+		},
+		{
+			name:     "Trailing comma on city",
+			input:    ParsedAddress{City: "Springfield,"},
+			expected: ParsedAddress{City: "Springfield"},
+		},
+		{
+			name:     "Leading punctuation and spaces",
+			input:    ParsedAddress{Street: ", main st", City: " ;Springfield. "},
+			expected: ParsedAddress{Street: "Main St", City: "Springfield"},
+		},
+		{
+			name:     "Internal hyphens and apostrophes kept",
+			input:    ParsedAddress{Street: "O'Brien-Smith.", City: "Wilkes-Barre,"},
+			expected: ParsedAddress{Street: "O'brien-Smith", City: "Wilkes-Barre"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.input
+			got.Normalize()
+			if got != tt.expected {
+				t.Errorf("got %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+
 	p := NewParser()
-	addr := "1005 N Gravenstein Highway, Suite 500, Sebastopol, CA 95472"
+	result, err := p.ParseLocation("123 Main St, Springfield., IL 62701")
+	if err != nil {
+		t.Fatalf("ParseLocation() error = %v", err)
+	}
+	if result.Address == nil || result.Address.City != "Springfield" {
+		t.Errorf("got %+v, want city Springfield", result.Address)
+	}
+}
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		p.ParseLocation(addr)
+func TestSpelledNumbers(t *testing.T) {
+	p := NewParserWithOptions(ParseOptions{SpelledNumbers: true})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:  "Spelled house number",
+			input: "One Infinite Loop",
+			expected: ParsedAddress{
+				Number: "1",
+				Street: "Infinite",
+				Type:   "loop",
+			},
+		},
+		{
+			name:  "Spelled unit number",
+			input: "123 Main St Apt Two",
+			expected: ParsedAddress{
+				Number:      "123",
+				Street:      "Main",
+				Type:        "st",
+				SecUnitType: "Apt",
+				SecUnitNum:  "2",
+			},
+		},
+		{
+			name:  "Hyphenated tens",
+			input: "Ninety-Nine Oak Ave",
+			expected: ParsedAddress{
+				Number: "99",
+				Street: "Oak",
+				Type:   "ave",
+			},
+		},
+		{
+			name:  "Hundreds",
+			input: "Three Hundred Five Elm St",
+			expected: ParsedAddress{
+				Number: "305",
+				Street: "Elm",
+				Type:   "st",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if result.Number != tt.expected.Number {
+				t.Errorf("Number: got %q, want %q", result.Number, tt.expected.Number)
+			}
+			if result.Street != tt.expected.Street {
+				t.Errorf("Street: got %q, want %q", result.Street, tt.expected.Street)
+			}
+			if result.Type != tt.expected.Type {
+				t.Errorf("Type: got %q, want %q", result.Type, tt.expected.Type)
+			}
+			if result.SecUnitType != tt.expected.SecUnitType {
+				t.Errorf("SecUnitType: got %q, want %q", result.SecUnitType, tt.expected.SecUnitType)
+			}
+			if result.SecUnitNum != tt.expected.SecUnitNum {
+				t.Errorf("SecUnitNum: got %q, want %q", result.SecUnitNum, tt.expected.SecUnitNum)
+			}
+		})
+	}
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		result := NewParser().ParseAddress("One Infinite Loop")
+		if result.Number != "" {
+			t.Errorf("Number: got %q, want empty", result.Number)
+		}
+	})
+}
+
+func TestParseAddressLocality(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:  "Unit before a multi-word city",
+			input: "123 Main St Apt 4B San Francisco CA 94105",
+			expected: ParsedAddress{
+				Number:      "123",
+				Street:      "Main",
+				Type:        "st",
+				SecUnitType: "Apt",
+				SecUnitNum:  "4B",
+				City:        "San Francisco",
+				State:       "CA",
+				ZIP:         "94105",
+			},
+		},
+		{
+			name:  "City and state in one segment",
+			input: "789 Oak Ave, Portland OR 97201",
+			expected: ParsedAddress{
+				Number: "789",
+				Street: "Oak",
+				Type:   "ave",
+				City:   "Portland",
+				State:  "OR",
+				ZIP:    "97201",
+			},
+		},
+		{
+			name:  "Directional suffix stays out of the city",
+			input: "123 Main St NW Springfield IL",
+			expected: ParsedAddress{
+				Number: "123",
+				Street: "Main",
+				Type:   "st",
+				Suffix: "NW",
+				City:   "Springfield",
+				State:  "IL",
+			},
+		},
+		{
+			name:  "Lowercase street type ends the city",
+			input: "123 main st springfield il",
+			expected: ParsedAddress{
+				Number: "123",
+				Street: "Main",
+				Type:   "st",
+				City:   "Springfield",
+				State:  "IL",
+			},
+		},
+		{
+			name:  "Unit word inside a street name",
+			input: "10 Flint Ave, Detroit, MI",
+			expected: ParsedAddress{
+				Number: "10",
+				Street: "Flint",
+				Type:   "ave",
+				City:   "Detroit",
+				State:  "MI",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressBuildingName(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:  "Complex name set off by a dash",
+			input: "Sunset Apartments - 123 Main St Apt 5, Portland OR 97201",
+			expected: ParsedAddress{
+				BuildingName: "Sunset Apartments",
+				Number:       "123",
+				Street:       "Main",
+				Type:         "st",
+				SecUnitType:  "Apt",
+				SecUnitNum:   "5",
+				City:         "Portland",
+				State:        "OR",
+				ZIP:          "97201",
+			},
+		},
+		{
+			name:  "Building name set off by a comma",
+			input: "The Plaza, 768 5th Ave",
+			expected: ParsedAddress{
+				BuildingName: "The Plaza",
+				Number:       "768",
+				Street:       "5th",
+				Type:         "ave",
+			},
+		},
+		{
+			name:  "Building name containing a unit word",
+			input: "Empire State Building, 350 5th Ave",
+			expected: ParsedAddress{
+				BuildingName: "Empire State Building",
+				Number:       "350",
+				Street:       "5th",
+				Type:         "ave",
+			},
+		},
+		{
+			name:  "Directional prefix stays with the street",
+			input: "The Plaza, 768 N 5th Ave",
+			expected: ParsedAddress{
+				BuildingName: "The Plaza",
+				Number:       "768",
+				Prefix:       "N",
+				Street:       "5th",
+				Type:         "ave",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressHashUnitBeforeLocality(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Single line", "123 Main St #5 Portland OR 97201"},
+		{"Space after hash", "123 Main St # 5 Portland OR 97201"},
+		{"Comma before locality", "123 Main St #5, Portland, OR 97201"},
+	}
+
+	expected := ParsedAddress{
+		Number:      "123",
+		Street:      "Main",
+		Type:        "st",
+		SecUnitType: "#",
+		SecUnitNum:  "5",
+		City:        "Portland",
+		State:       "OR",
+		ZIP:         "97201",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != expected {
+				t.Errorf("got %+v, want %+v", *result, expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressUnitRange(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name        string
+		input       string
+		expectedTyp string
+		expectedNum string
+	}{
+		{"Suites through", "123 Main St Suites 100 through 110", "Suites", "100-110"},
+		{"Floors thru", "500 Oak Ave Floors 3 thru 5, Portland OR 97201", "Floors", "3-5"},
+		{"Single suite unchanged", "123 Main St Suite 100", "Suite", "100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if result.SecUnitType != tt.expectedTyp {
+				t.Errorf("SecUnitType: got %q, want %q", result.SecUnitType, tt.expectedTyp)
+			}
+			if result.SecUnitNum != tt.expectedNum {
+				t.Errorf("SecUnitNum: got %q, want %q", result.SecUnitNum, tt.expectedNum)
+			}
+			if result.Street == "" || result.Type == "" {
+				t.Errorf("street lost: %+v", *result)
+			}
+		})
+	}
+}
+
+func TestParseAddressDirectionalStreetName(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Directional compound is the street name",
+			input:    "100 North Shore Dr",
+			expected: ParsedAddress{Number: "100", Street: "North Shore", Type: "dr"},
+		},
+		{
+			name:     "Directional before a plain name is a prefix",
+			input:    "100 North Main St",
+			expected: ParsedAddress{Number: "100", Prefix: "N", Street: "Main", Type: "st"},
+		},
+		{
+			name:     "Directional-only street name",
+			input:    "100 North Ave",
+			expected: ParsedAddress{Number: "100", Street: "North", Type: "ave"},
+		},
+		{
+			name:     "Lettered street with a quadrant",
+			input:    "N St NW, Washington DC",
+			expected: ParsedAddress{Street: "N", Type: "st", Suffix: "NW", City: "Washington", State: "DC"},
+		},
+		{
+			name:     "Lettered street with number and ZIP",
+			input:    "1200 E St SE, Washington, DC 20003",
+			expected: ParsedAddress{Number: "1200", Street: "E", Type: "st", Suffix: "SE", City: "Washington", State: "DC", ZIP: "20003"},
+		},
+		{
+			name:     "Letter before a name is a prefix",
+			input:    "N Main St",
+			expected: ParsedAddress{Prefix: "N", Street: "Main", Type: "st"},
+		},
+		{
+			name:     "Letter before a name with a suffix is a prefix",
+			input:    "100 N Main St NW",
+			expected: ParsedAddress{Number: "100", Prefix: "N", Street: "Main", Type: "st", Suffix: "NW"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressQuadrants(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "NW",
+			input:    "1600 Pennsylvania Ave NW, Washington, DC 20500",
+			expected: ParsedAddress{Number: "1600", Street: "Pennsylvania", Type: "ave", Suffix: "NW", City: "Washington", State: "DC", ZIP: "20500"},
+		},
+		{
+			name:     "NE is not Nebraska",
+			input:    "1 First St NE",
+			expected: ParsedAddress{Number: "1", Street: "First", Type: "st", Suffix: "NE"},
+		},
+		{
+			name:     "SE",
+			input:    "700 Pennsylvania Ave SE Washington DC",
+			expected: ParsedAddress{Number: "700", Street: "Pennsylvania", Type: "ave", Suffix: "SE", City: "Washington", State: "DC"},
+		},
+		{
+			name:     "SW spelled out",
+			input:    "800 Southern Avenue Southwest",
+			expected: ParsedAddress{Number: "800", Street: "Southern", Type: "ave", Suffix: "SW"},
+		},
+		{
+			name:     "Nebraska after a city",
+			input:    "123 Main St Omaha NE 68102",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Omaha", State: "NE", ZIP: "68102"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressSaintStreetName(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Leading St is Saint",
+			input:    "123 St James Pl",
+			expected: ParsedAddress{Number: "123", Street: "St James", Type: "pl"},
+		},
+		{
+			name:     "Abbreviation period is dropped",
+			input:    "123 St. James Pl",
+			expected: ParsedAddress{Number: "123", Street: "St James", Type: "pl"},
+		},
+		{
+			name:     "Saint name with St type and locality",
+			input:    "123 St James St, Boston, MA 02101",
+			expected: ParsedAddress{Number: "123", Street: "St James", Type: "st", City: "Boston", State: "MA", ZIP: "02101"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressPlacePrefixes(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{"St city after street type", "123 Main St St Louis MO 63101", ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "St Louis", State: "MO", ZIP: "63101"}},
+		{"St. city with comma", "123 Main St, St. Louis, MO 63101", ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "St Louis", State: "MO", ZIP: "63101"}},
+		{"Mt city after street type", "100 Oak Ave Mt Vernon NY 10550", ParsedAddress{Number: "100", Street: "Oak", Type: "ave", City: "Mt Vernon", State: "NY", ZIP: "10550"}},
+		{"Mount city after street type", "12 Main St Mount Vernon NY", ParsedAddress{Number: "12", Street: "Main", Type: "st", City: "Mount Vernon", State: "NY"}},
+		{"Ft city after street type", "200 Elm St Ft Worth TX 76102", ParsedAddress{Number: "200", Street: "Elm", Type: "st", City: "Ft Worth", State: "TX", ZIP: "76102"}},
+		{"Ft city after suffix", "12 Main St N Ft Myers FL", ParsedAddress{Number: "12", Street: "Main", Type: "st", Suffix: "N", City: "Ft Myers", State: "FL"}},
+		{"St street", "77 St Marks Pl, New York, NY", ParsedAddress{Number: "77", Street: "St Marks", Type: "pl", City: "New York", State: "NY"}},
+		{"Mt. street", "12 Mt. Vernon St, Boston, MA", ParsedAddress{Number: "12", Street: "Mt Vernon", Type: "st", City: "Boston", State: "MA"}},
+		{"Ft street", "45 Ft Hamilton Pkwy Brooklyn NY", ParsedAddress{Number: "45", Street: "Ft Hamilton", Type: "pkwy", City: "Brooklyn", State: "NY"}},
+		{"Street type before a plain city", "123 Main St Louis MO", ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Louis", State: "MO"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFrenchCanadianLocale(t *testing.T) {
+	p := NewParserWithOptions(ParseOptions{Locale: LocaleFrenchCanadian})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:  "Rue before the name",
+			input: "123 Rue Saint-Denis, Montréal, QC",
+			expected: ParsedAddress{
+				Number: "123",
+				Street: "Saint-Denis",
+				Type:   "rue",
+				City:   "Montréal",
+				State:  "QC",
+			},
+		},
+		{
+			name:  "Boulevard before the name",
+			input: "789 Boulevard René-Lévesque, Québec, QC",
+			expected: ParsedAddress{
+				Number: "789",
+				Street: "René-Lévesque",
+				Type:   "boul",
+				City:   "Québec",
+				State:  "QC",
+			},
+		},
+		{
+			name:  "Montée before the name",
+			input: "10 Montée Sainte-Julie",
+			expected: ParsedAddress{
+				Number: "10",
+				Street: "Sainte-Julie",
+				Type:   "montée",
+			},
+		},
+		{
+			name:  "Postal code after the province",
+			input: "1000 Rue Sherbrooke, Montreal QC H2Z 1A1",
+			expected: ParsedAddress{
+				Number: "1000",
+				Street: "Sherbrooke",
+				Type:   "rue",
+				City:   "Montreal",
+				State:  "QC",
+				ZIP:    "H2Z 1A1",
+			},
+		},
+		{
+			name:  "Postal code without the space",
+			input: "100 Queen St W, Toronto, ON m5h2n2",
+			expected: ParsedAddress{
+				Number: "100",
+				Street: "Queen",
+				Type:   "st",
+				Suffix: "W",
+				City:   "Toronto",
+				State:  "ON",
+				ZIP:    "M5H 2N2",
+			},
+		},
+		{
+			name:  "English address still parses",
+			input: "500 Sherbrooke St, Montréal, QC",
+			expected: ParsedAddress{
+				Number: "500",
+				Street: "Sherbrooke",
+				Type:   "st",
+				City:   "Montréal",
+				State:  "QC",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Provinces are not recognized without the locale", func(t *testing.T) {
+		result := NewParser().ParseAddress("123 Rue Saint-Denis, Montréal, QC")
+		if result.State != "" {
+			t.Errorf("State: got %q, want empty", result.State)
+		}
+	})
+}
+
+func TestSpanishUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:  "Depto translated",
+			input: "Calle 5 #123 Depto 4",
+			expected: ParsedAddress{
+				Street:       "Calle 5",
+				SecUnitType:  "#",
+				SecUnitNum:   "123",
+				SecUnitType2: "Apt",
+				SecUnitNum2:  "4",
+			},
+		},
+		{
+			name:   "Depto kept with the locale",
+			locale: LocaleSpanish,
+			input:  "Calle 5 #123 Depto 4",
+			expected: ParsedAddress{
+				Number:      "123",
+				Street:      "Calle 5",
+				SecUnitType: "Depto",
+				SecUnitNum:  "4",
+			},
+		},
+		{
+			name:  "Piso translated",
+			input: "Av Reforma 222 Piso 2, Ciudad de Mexico",
+			expected: ParsedAddress{
+				Street:      "Av Reforma 222",
+				SecUnitType: "Fl",
+				SecUnitNum:  "2",
+				City:        "Ciudad de Mexico",
+			},
+		},
+		{
+			name:   "Piso kept with a dashed house number",
+			locale: LocaleSpanish,
+			input:  "Carrera 7 # 12-34 Piso 2, Bogota",
+			expected: ParsedAddress{
+				Number:      "12-34",
+				Street:      "Carrera 7",
+				SecUnitType: "Piso",
+				SecUnitNum:  "2",
+				City:        "Bogota",
+			},
+		},
+		{
+			name:  "Local translated",
+			input: "45 Main St Local B, Springfield, IL",
+			expected: ParsedAddress{
+				Number:      "45",
+				Street:      "Main",
+				Type:        "st",
+				SecUnitType: "Ste",
+				SecUnitNum:  "B",
+				City:        "Springfield",
+				State:       "IL",
+			},
+		},
+		{
+			name:  "Local as a street name",
+			input: "123 Local St, Springfield, IL",
+			expected: ParsedAddress{
+				Number: "123",
+				Street: "Local",
+				Type:   "st",
+				City:   "Springfield",
+				State:  "IL",
+			},
+		},
+		{
+			name:   "Hash unit after a house number with the locale",
+			locale: LocaleSpanish,
+			input:  "123 Main St #4, Springfield, IL",
+			expected: ParsedAddress{
+				Number:      "123",
+				Street:      "Main",
+				Type:        "st",
+				SecUnitType: "#",
+				SecUnitNum:  "4",
+				City:        "Springfield",
+				State:       "IL",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParserWithOptions(ParseOptions{Locale: tt.locale})
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func BenchmarkParseAddress(b *testing.B) {
+	p := NewParser()
+	addr := "1005 N Gravenstein Highway, Suite 500, Sebastopol, CA 95472"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.ParseAddress(addr)
+	}
+}
+
+func BenchmarkParseLocation(b *testing.B) {
+	p := NewParser()
+	addr := "1005 N Gravenstein Highway, Suite 500, Sebastopol, CA 95472"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.ParseLocation(addr)
+	}
+}
+
+func TestParseLocationContext(t *testing.T) {
+	p := NewParser()
+
+	t.Run("Background context parses normally", func(t *testing.T) {
+		result, err := p.ParseLocationContext(context.Background(), "1005 N Gravenstein Hwy Sebastopol CA 95472")
+		if err != nil {
+			t.Fatalf("ParseLocationContext failed: %v", err)
+		}
+		if result.Type != "address" {
+			t.Errorf("Type: got %q, want %q", result.Type, "address")
+		}
+	})
+
+	t.Run("Cancelled context returns ctx.Err()", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		start := time.Now()
+		result, err := p.ParseLocationContext(ctx, "1005 N Gravenstein Hwy Sebastopol CA 95472")
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("error: got %v, want %v", err, context.Canceled)
+		}
+		if result != nil {
+			t.Errorf("expected nil result, got %+v", result)
+		}
+		if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+			t.Errorf("cancelled parse took %v, expected prompt return", elapsed)
+		}
+	})
+
+	t.Run("Expired deadline returns DeadlineExceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		_, err := p.ParseLocationContext(ctx, "Mission St and Valencia St")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("error: got %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
+
+func TestParseAsDetectedType(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name         string
+		input        string
+		parseType    string
+		wantDetected string
+		wantWarning  bool
+	}{
+		{"Intersection parsed as standard", "Main and Elm", "standard", "intersection", true},
+		{"Address parsed as standard", "123 Main St", "standard", "address", false},
+		{"Address parsed as informal", "123 Main St", "informal", "address", false},
+		{"Address parsed as PO box", "123 Main St", "po_box", "address", true},
+		{"Box without a PO box reading", "Box 45, Town ST", "standard", "address", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.ParseAs(context.Background(), tt.input, tt.parseType)
+			if err != nil {
+				t.Fatalf("ParseAs() failed: %v", err)
+			}
+			if result.DetectedType != tt.wantDetected {
+				t.Errorf("DetectedType: got %q, want %q", result.DetectedType, tt.wantDetected)
+			}
+			if got := len(result.Warnings) > 0; got != tt.wantWarning {
+				t.Errorf("Warnings: got %v, want warning %v", result.Warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestReportPresence(t *testing.T) {
+	p := NewParserWithOptions(ParseOptions{ReportPresence: true})
+
+	result, err := p.ParseLocation("PO Box 1234, Denver, CO 80201")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Type != "po_box" {
+		t.Fatalf("Type: got %q, want po_box", result.Type)
+	}
+
+	want := map[string]bool{
+		"attention":     false,
+		"care_of":       false,
+		"sec_unit_type": true,
+		"sec_unit_num":  true,
+		"city":          true,
+		"county":        false,
+		"state":         true,
+		"zip":           true,
+		"plus4":         false, // attempted, not present
+		"country":       false,
+	}
+	if len(result.Presence) != len(want) {
+		t.Errorf("Presence: got %v, want %v", result.Presence, want)
+	}
+	for field, present := range want {
+		got, ok := result.Presence[field]
+		if !ok {
+			t.Errorf("Presence[%q]: missing, want %v", field, present)
+		} else if got != present {
+			t.Errorf("Presence[%q]: got %v, want %v", field, got, present)
+		}
+	}
+	// The PO box parser never looks for a street
+	if _, ok := result.Presence["street"]; ok {
+		t.Errorf("Presence[%q]: should be absent for po_box", "street")
+	}
+
+	addr, err := p.ParseAs(context.Background(), "123 Main St", "standard")
+	if err != nil {
+		t.Fatalf("ParseAs() failed: %v", err)
+	}
+	if len(addr.Presence) != len(addressFields) {
+		t.Errorf("Standard presence: got %d fields, want %d", len(addr.Presence), len(addressFields))
+	}
+	if !addr.Presence["street"] || addr.Presence["sec_unit_num"] {
+		t.Errorf("Standard presence: got %v", addr.Presence)
+	}
+
+	// Without the option the map is left nil
+	plain, _ := NewParser().ParseLocation("123 Main St")
+	if plain.Presence != nil {
+		t.Errorf("Presence without option: got %v, want nil", plain.Presence)
+	}
+}
+
+func TestParseLocationPartial(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		input    string
+		expected ParsedAddress
+	}{
+		{"95472", ParsedAddress{ZIP: "95472"}},
+		{"CA", ParsedAddress{State: "CA"}},
+		{"Sebastopol, CA", ParsedAddress{City: "Sebastopol", State: "CA"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			if result.Type != "address" {
+				t.Fatalf("Type: got %q, want address", result.Type)
+			}
+			if !result.Partial {
+				t.Errorf("Partial: got false, want true")
+			}
+			if *result.Address != tt.expected {
+				t.Errorf("got %+v, want %+v", *result.Address, tt.expected)
+			}
+		})
+	}
+
+	full, err := p.ParseLocation("1005 N Gravenstein Hwy, Sebastopol, CA 95472")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if full.Partial {
+		t.Errorf("Partial for a full address: got true, want false")
+	}
+}
+
+func TestParseAddressUnitWords(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Rear",
+			input:    "123 Main St Rear",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Rear"},
+		},
+		{
+			name:     "Upper",
+			input:    "45 Oak Ave Upper",
+			expected: ParsedAddress{Number: "45", Street: "Oak", Type: "ave", SecUnitType: "Upper"},
+		},
+		{
+			name:     "Abbreviation is canonicalized",
+			input:    "10 Elm St N Bsmt",
+			expected: ParsedAddress{Number: "10", Street: "Elm", Type: "st", Suffix: "N", SecUnitType: "Basement"},
+		},
+		{
+			name:     "Penthouse before locality",
+			input:    "10 Elm St Penthouse, Boston, MA",
+			expected: ParsedAddress{Number: "10", Street: "Elm", Type: "st", SecUnitType: "Penthouse", City: "Boston", State: "MA"},
+		},
+		{
+			name:     "Half unit",
+			input:    "123 Main St 1/2",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitNum: "1/2"},
+		},
+		{
+			name:     "Unit word naming the street",
+			input:    "10 Front St",
+			expected: ParsedAddress{Number: "10", Street: "Front", Type: "st"},
+		},
+		{
+			name:     "Unit word in the street name and as a unit",
+			input:    "10 Upper Ridge Rd Lower",
+			expected: ParsedAddress{Number: "10", Street: "Upper Ridge", Type: "rd", SecUnitType: "Lower"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressUnitPosition(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		leading  string
+		trailing string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Unit without comma",
+			leading:  "Apt 4B 123 Main St",
+			trailing: "123 Main St Apt 4B",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "4B"},
+		},
+		{
+			name:     "Unit segment",
+			leading:  "Apt 4B, 123 Main St, Springfield, IL 62704",
+			trailing: "123 Main St, Apt 4B, Springfield, IL 62704",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "4B", City: "Springfield", State: "IL", ZIP: "62704"},
+		},
+		{
+			name:     "Numeric unit before the house number",
+			leading:  "Apt 100 200 N Main St",
+			trailing: "200 N Main St Apt 100",
+			expected: ParsedAddress{Number: "200", Prefix: "N", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "100"},
+		},
+		{
+			name:     "Five-digit unit is not a ZIP",
+			leading:  "Suite 12345, 100 Main St, Reno, NV",
+			trailing: "100 Main St Suite 12345, Reno, NV",
+			expected: ParsedAddress{Number: "100", Street: "Main", Type: "st", SecUnitType: "Suite", SecUnitNum: "12345", City: "Reno", State: "NV"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, input := range []string{tt.leading, tt.trailing} {
+				if result := p.ParseAddress(input); *result != tt.expected {
+					t.Errorf("%q: got %+v, want %+v", input, *result, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAddressTrailingUnit(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Suite segment after the ZIP",
+			input:    "123 Main St, Springfield IL 62704, Suite 5",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Suite", SecUnitNum: "5", City: "Springfield", State: "IL", ZIP: "62704"},
+		},
+		{
+			name:     "Unit after the ZIP+4 without comma",
+			input:    "123 Main St, Springfield IL 62704-1234 Apt 4B",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "4B", City: "Springfield", State: "IL", ZIP: "62704", Plus4: "1234"},
+		},
+		{
+			name:     "Unit after the state",
+			input:    "123 Main St, Springfield, IL, Unit 7",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Unit", SecUnitNum: "7", City: "Springfield", State: "IL"},
+		},
+		{
+			name:     "Unit word after the ZIP",
+			input:    "123 Main St, Springfield IL 62704, Rear",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Rear", City: "Springfield", State: "IL", ZIP: "62704"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressGluedUnit(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "APT glued to number",
+			input:    "123 Main St APT4B",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "APT", SecUnitNum: "4B"},
+		},
+		{
+			name:  "STE glued to number",
+			input: "100 Market St STE200, San Francisco, CA",
+			expected: ParsedAddress{
+				Number:      "100",
+				Street:      "Market",
+				Type:        "st",
+				SecUnitType: "STE",
+				SecUnitNum:  "200",
+				City:        "San Francisco",
+				State:       "CA",
+			},
+		},
+		{
+			name:  "Two glued units",
+			input: "123 Main St Apt4B Bldg2",
+			expected: ParsedAddress{
+				Number:       "123",
+				Street:       "Main",
+				Type:         "st",
+				SecUnitType:  "Apt",
+				SecUnitNum:   "4B",
+				SecUnitType2: "Bldg",
+				SecUnitNum2:  "2",
+			},
+		},
+		{
+			name:     "Designator letters starting a street name",
+			input:    "12 Aptos Rd",
+			expected: ParsedAddress{Number: "12", Street: "Aptos", Type: "rd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressSecondUnit(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:  "Units in separate comma segments",
+			input: "123 Main St, Apt 4, Bldg B, Portland OR",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "4",
+				SecUnitType2: "Bldg", SecUnitNum2: "B", City: "Portland", State: "OR"},
+		},
+		{
+			name:  "Units on one line",
+			input: "123 Main St Bldg B Apt 4 Portland OR 97201",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Bldg", SecUnitNum: "B",
+				SecUnitType2: "Apt", SecUnitNum2: "4", City: "Portland", State: "OR", ZIP: "97201"},
+		},
+		{
+			name:     "Single unit leaves the second empty",
+			input:    "123 Main St, Apt 4, Portland OR",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "4", City: "Portland", State: "OR"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressCareOf(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Lowercase c/o segment",
+			input:    "c/o Jane Doe, 500 Main St, Town ST 12345",
+			expected: ParsedAddress{CareOf: "Jane Doe", Number: "500", Street: "Main", Type: "st", City: "Town St", ZIP: "12345"},
+		},
+		{
+			name:     "Uppercase C/O without a comma",
+			input:    "C/O Acme Corp 500 Main St, Boston, MA 02101",
+			expected: ParsedAddress{CareOf: "Acme Corp", Number: "500", Street: "Main", Type: "st", City: "Boston", State: "MA", ZIP: "02101"},
+		},
+		{
+			name:  "Care-of sign before a building name",
+			input: "\u2105 Jane Doe, The Plaza, 768 5th Ave, New York, NY",
+			expected: ParsedAddress{CareOf: "Jane Doe", BuildingName: "The Plaza", Number: "768", Street: "5th", Type: "ave",
+				City: "New York", State: "NY"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressAttention(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Comma-delimited",
+			input:    "Attn: Accounts Payable, 500 Main St, Boston, MA 02101",
+			expected: ParsedAddress{Attention: "Accounts Payable", Number: "500", Street: "Main", Type: "st", City: "Boston", State: "MA", ZIP: "02101"},
+		},
+		{
+			name:     "Newline-delimited without a colon",
+			input:    "ATTN Billing Dept\n500 Main St, Boston, MA 02101",
+			expected: ParsedAddress{Attention: "Billing Dept", Number: "500", Street: "Main", Type: "st", City: "Boston", State: "MA", ZIP: "02101"},
+		},
+		{
+			name:  "Attention and care-of",
+			input: "Attention: Billing, c/o Acme Corp, 500 Main St, Boston, MA",
+			expected: ParsedAddress{Attention: "Billing", CareOf: "Acme Corp", Number: "500", Street: "Main", Type: "st",
+				City: "Boston", State: "MA"},
+		},
+		{
+			name:     "Street name starting with Atten",
+			input:    "12 Attenborough Way",
+			expected: ParsedAddress{Number: "12", Street: "Attenborough", Type: "way"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitGluedTokens(t *testing.T) {
+	p := NewParserWithOptions(ParseOptions{SplitGluedTokens: true})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Type and directional glued to the name",
+			input:    "100 MainStN",
+			expected: ParsedAddress{Number: "100", Street: "Main", Type: "st", Suffix: "N"},
+		},
+		{
+			name:     "Camel-case name stays whole",
+			input:    "100 MacArthurBlvd",
+			expected: ParsedAddress{Number: "100", Street: "Macarthur", Type: "blvd"},
+		},
+		{
+			name:     "Spaced input is unchanged",
+			input:    "100 Main St N",
+			expected: ParsedAddress{Number: "100", Street: "Main", Type: "st", Suffix: "N"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+
+	// Off by default
+	if got := NewParser().ParseAddress("100 MainStN"); got.Type != "" {
+		t.Errorf("Without option: got Type %q, want none", got.Type)
+	}
+}
+
+func TestStripContacts(t *testing.T) {
+	p := NewParserWithOptions(ParseOptions{StripContacts: true})
+
+	tests := []struct {
+		name      string
+		input     string
+		expected  ParsedAddress
+		wantPhone string
+		wantEmail string
+	}{
+		{
+			name:      "Phone and email after the street",
+			input:     "123 Main St 555-123-4567 jane@example.com",
+			expected:  ParsedAddress{Number: "123", Street: "Main", Type: "st"},
+			wantPhone: "555-123-4567",
+			wantEmail: "jane@example.com",
+		},
+		{
+			name:      "Trailing phone is not the ZIP",
+			input:     "123 Main St, Springfield, IL 62701 (217) 555-0100",
+			expected:  ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62701"},
+			wantPhone: "(217) 555-0100",
+		},
+		{
+			name:      "Email segment is not the city",
+			input:     "500 Oak Ave, Email: jane@example.com, Reno, NV 89501",
+			expected:  ParsedAddress{Number: "500", Street: "Oak", Type: "ave", City: "Reno", State: "NV", ZIP: "89501"},
+			wantEmail: "jane@example.com",
+		},
+		{
+			name:     "ZIP+4 is not a phone",
+			input:    "123 Main St, Springfield, IL 62701-1234",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62701", Plus4: "1234"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			if result.Address == nil || *result.Address != tt.expected {
+				t.Errorf("got %+v, want %+v", result.Address, tt.expected)
+			}
+			if result.Phone != tt.wantPhone || result.Email != tt.wantEmail {
+				t.Errorf("contacts: got %q, %q, want %q, %q", result.Phone, result.Email, tt.wantPhone, tt.wantEmail)
+			}
+		})
+	}
+
+	// Off by default
+	result, err := NewParser().ParseLocation("123 Main St 555-123-4567 jane@example.com")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Phone != "" || result.Email != "" {
+		t.Errorf("Without option: got %q, %q, want none", result.Phone, result.Email)
+	}
+}
+
+func TestParseLocationNotes(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name      string
+		input     string
+		expected  ParsedAddress
+		wantNotes string
+	}{
+		{
+			name:      "Parenthesized note",
+			input:     "123 Main St (use side entrance), Town ST 12345",
+			expected:  ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Town St", ZIP: "12345"},
+			wantNotes: "use side entrance",
+		},
+		{
+			name:      "Trailing instruction",
+			input:     "123 Main St, Springfield IL 62701 leave at door",
+			expected:  ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62701"},
+			wantNotes: "leave at door",
+		},
+		{
+			name:      "Instruction segment is not the city",
+			input:     "123 Main St, gate code 1234, Springfield IL 62701",
+			expected:  ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62701"},
+			wantNotes: "gate code 1234",
+		},
+		{
+			name:      "Several notes",
+			input:     "45 Oak Ave (ring buzzer) - leave at front door, Springfield IL 62701",
+			expected:  ParsedAddress{Number: "45", Street: "Oak", Type: "ave", City: "Springfield", State: "IL", ZIP: "62701"},
+			wantNotes: "ring buzzer; leave at front door",
+		},
+		{
+			name:     "Street named like an instruction",
+			input:    "12 Ring Rd, Springfield IL 62701",
+			expected: ParsedAddress{Number: "12", Street: "Ring", Type: "rd", City: "Springfield", State: "IL", ZIP: "62701"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			if result.Address == nil || *result.Address != tt.expected {
+				t.Errorf("got %+v, want %+v", result.Address, tt.expected)
+			}
+			if result.Notes != tt.wantNotes {
+				t.Errorf("notes: got %q, want %q", result.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestAssumedRegion(t *testing.T) {
+	p := NewParserWithOptions(ParseOptions{AssumeState: "California", AssumeZIPPrefix: "95"})
+
+	tests := []struct {
+		name         string
+		input        string
+		wantState    string
+		wantWarnings int
+	}{
+		{"Assumed state fills in", "1005 Gravenstein Hwy N, Sebastopol 95472", "CA", 0},
+		{"Matching state", "1005 Gravenstein Hwy N, Sebastopol, CA 95472", "CA", 0},
+		{"Conflicting state and ZIP are kept with warnings", "123 Main St, Reno, NV 89501", "NV", 2},
+		{"Conflicting ZIP only", "123 Main St, Los Angeles, CA 90012", "CA", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			if result.Address == nil || result.Address.State != tt.wantState {
+				t.Errorf("State: got %+v, want %q", result.Address, tt.wantState)
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings: got %v, want %d", result.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestParseDuplicateZIPAndState(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "House number equal to the ZIP",
+			input:    "80014 E 14000 Dr, Denver CO 80014",
+			expected: ParsedAddress{Number: "80014", Prefix: "E", Street: "14000", Type: "dr", City: "Denver", State: "CO", ZIP: "80014"},
+		},
+		{
+			name:     "Single line",
+			input:    "80014 E 14000 Dr Denver CO 80014",
+			expected: ParsedAddress{Number: "80014", Prefix: "E", Street: "14000", Type: "dr", City: "Denver", State: "CO", ZIP: "80014"},
+		},
+		{
+			name:     "PO box city with a state-like word",
+			input:    "PO Box 5, La Jolla, CA 92037",
+			expected: ParsedAddress{SecUnitType: "PO Box", SecUnitNum: "5", City: "La Jolla", State: "CA", ZIP: "92037"},
+		},
+		{
+			name:     "PO box city with a two-letter word",
+			input:    "PO Box 5, El Paso TX 79901",
+			expected: ParsedAddress{SecUnitType: "PO Box", SecUnitNum: "5", City: "El Paso", State: "TX", ZIP: "79901"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			if result.Address == nil || *result.Address != tt.expected {
+				t.Errorf("got %+v, want %+v", result.Address, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetPreprocessor(t *testing.T) {
+	p := NewParser()
+	var seen string
+	p.SetPreprocessor(func(s string) string {
+		seen = s
+		return strings.ReplaceAll(s, "Saint ", "St ")
+	})
+
+	result, err := p.ParseLocation("  123 Main St,\tSaint Louis, MO 63101 ")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	expected := ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "St Louis", State: "MO", ZIP: "63101"}
+	if result.Address == nil || *result.Address != expected {
+		t.Errorf("got %+v, want %+v", result.Address, expected)
+	}
+	// Runs after SanitizeInput
+	if want := "123 Main St, Saint Louis, MO 63101"; seen != want {
+		t.Errorf("preprocessor saw %q, want %q", seen, want)
+	}
+
+	// A preprocessor that empties the input is an empty input
+	p.SetPreprocessor(func(string) string { return " " })
+	if _, err := p.ParseLocation("123 Main St"); !errors.Is(err, ErrInputEmpty) {
+		t.Errorf("got error %v, want ErrInputEmpty", err)
+	}
+
+	// nil removes the hook
+	p.SetPreprocessor(nil)
+	result, err = p.ParseLocation("123 Main St, Saint Louis, MO 63101")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Address.City != "Saint Louis" {
+		t.Errorf("got city %q, want %q", result.Address.City, "Saint Louis")
+	}
+}
+
+func TestJoinSpacedZIP(t *testing.T) {
+	p := NewParserWithOptions(ParseOptions{JoinSpacedZIP: true})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "City, state and spaced ZIP",
+			input:    "Sebastopol CA 9 5 4 7 2",
+			expected: ParsedAddress{City: "Sebastopol", State: "CA", ZIP: "95472"},
+		},
+		{
+			name:     "Full address",
+			input:    "1005 Gravenstein Hwy N, Sebastopol, CA 9 5 4 7 2",
+			expected: ParsedAddress{Number: "1005", Street: "Gravenstein", Type: "hwy", Suffix: "N", City: "Sebastopol", State: "CA", ZIP: "95472"},
+		},
+		{
+			name:     "Digits not after a state are kept",
+			input:    "Main St 1 2 3 4 5",
+			expected: ParsedAddress{Street: "Main St 1 2 3 4 5"},
+		},
+		{
+			name:     "Four digits are kept",
+			input:    "Sebastopol CA 9 5 4 7",
+			expected: ParsedAddress{Street: "Sebastopol Ca 9 5 4 7"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			if result.Address == nil || *result.Address != tt.expected {
+				t.Errorf("got %+v, want %+v", result.Address, tt.expected)
+			}
+		})
+	}
+
+	// Off by default
+	result, err := NewParser().ParseLocation("Sebastopol CA 9 5 4 7 2")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Address != nil && result.Address.ZIP != "" {
+		t.Errorf("Without option: got ZIP %q, want none", result.Address.ZIP)
+	}
+}
+
+func TestPadZIP(t *testing.T) {
+	p := NewParserWithOptions(ParseOptions{PadZIP: true})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "City, state and short ZIP",
+			input:    "Town MA 1001",
+			expected: ParsedAddress{City: "Town", State: "MA", ZIP: "01001"},
+		},
+		{
+			name:     "Full address",
+			input:    "1 Main St, Boston, MA 2108",
+			expected: ParsedAddress{Number: "1", Street: "Main", Type: "st", City: "Boston", State: "MA", ZIP: "02108"},
+		},
+		{
+			name:     "Short ZIP with plus4",
+			input:    "Newark NJ 7102-1234",
+			expected: ParsedAddress{City: "Newark", State: "NJ", ZIP: "07102", Plus4: "1234"},
+		},
+		{
+			name:     "Padded ZIP outside the state is kept",
+			input:    "Springfield IL 1001",
+			expected: ParsedAddress{Street: "Springfield Il 1001"},
+		},
+		{
+			name:     "Four digits not after a state are kept",
+			input:    "Main St 1001",
+			expected: ParsedAddress{Street: "Main St 1001"},
+		},
+		{
+			name:     "Five digit ZIP is unchanged",
+			input:    "Town MA 01001",
+			expected: ParsedAddress{City: "Town", State: "MA", ZIP: "01001"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			if result.Address == nil || *result.Address != tt.expected {
+				t.Errorf("got %+v, want %+v", result.Address, tt.expected)
+			}
+		})
+	}
+
+	// Off by default
+	result, err := NewParser().ParseLocation("Town MA 1001")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Address != nil && result.Address.ZIP != "" {
+		t.Errorf("Without option: got ZIP %q, want none", result.Address.ZIP)
+	}
+}
+
+func TestParseAddressZIPPosition(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Five-digit house number is not a ZIP",
+			input:    "12345 Main St",
+			expected: ParsedAddress{Number: "12345", Street: "Main", Type: "st"},
+		},
+		{
+			name:     "Five-digit house number with locality",
+			input:    "12345 Main St Springfield IL",
+			expected: ParsedAddress{Number: "12345", Street: "Main", Type: "st", City: "Springfield", State: "IL"},
+		},
+		{
+			name:     "Trailing ZIP wins over house number",
+			input:    "12345 Main St, Springfield, IL 62701",
+			expected: ParsedAddress{Number: "12345", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62701"},
+		},
+		{
+			name:     "Implausible ZIP is ignored",
+			input:    "100 Main St 00000",
+			expected: ParsedAddress{Number: "100", Street: "Main St 00000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressTownship(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Township with commas",
+			input:    "123 Main St, Hamilton Township, NJ",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Hamilton Township", State: "NJ"},
+		},
+		{
+			name:     "Township single line",
+			input:    "123 Main St Hamilton Township NJ",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Hamilton Township", State: "NJ"},
+		},
+		{
+			name:     "Twp abbreviation",
+			input:    "123 Main St Bloomfield Twp MI",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Bloomfield Twp", State: "MI"},
+		},
+		{
+			name:     "Township name holding a street type",
+			input:    "45 Oak Ave Cherry Hill Township NJ 08002",
+			expected: ParsedAddress{Number: "45", Street: "Oak", Type: "ave", City: "Cherry Hill Township", State: "NJ", ZIP: "08002"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressSingleLineBounds(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Street type alone",
+			input:    "ST",
+			expected: ParsedAddress{Type: "st"},
+		},
+		{
+			name:     "Word and street type",
+			input:    "X ST",
+			expected: ParsedAddress{Street: "X", Type: "st"},
+		},
+		{
+			name:     "State code alone",
+			input:    "CA",
+			expected: ParsedAddress{State: "CA"},
+		},
+		{
+			name:     "Word and state code",
+			input:    "X CA",
+			expected: ParsedAddress{City: "X", State: "CA"},
+		},
+		{
+			name:     "Number and state code",
+			input:    "1 CA",
+			expected: ParsedAddress{Number: "1", State: "CA"},
+		},
+		{
+			name:     "Street type and state code",
+			input:    "St CA",
+			expected: ParsedAddress{Type: "st", State: "CA"},
+		},
+		{
+			name:     "Township marker alone before the state",
+			input:    "Twp NJ",
+			expected: ParsedAddress{City: "Twp", State: "NJ"},
+		},
+		{
+			name:     "Township marker right after the street",
+			input:    "1 Main St Twp NJ",
+			expected: ParsedAddress{Number: "1", Street: "Main", Type: "st", City: "Twp", State: "NJ"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBestGuessTieBreaking(t *testing.T) {
+	full := &ParseResult{
+		Type:       "address",
+		Address:    &ParsedAddress{Number: "1", Street: "Main", Type: "st", City: "Boston"},
+		Confidence: 0.75,
+	}
+	fewer := &ParseResult{
+		Type:       "address",
+		Address:    &ParsedAddress{Number: "1", Street: "Main", City: "Boston"},
+		Confidence: 0.75,
+	}
+	withZIP := &ParseResult{
+		Type:         "intersection",
+		Intersection: &ParsedIntersection{Street1: "Main", Street2: "Elm", ZIP: "02101"},
+		Confidence:   0.75,
+	}
+	noZIP := &ParseResult{
+		Type:       "address",
+		Address:    &ParsedAddress{Street: "Main", City: "Boston", State: "MA"},
+		Confidence: 0.75,
+	}
+	poBox := &ParseResult{
+		Type:       "po_box",
+		Address:    &ParsedAddress{SecUnitType: "PO Box", SecUnitNum: "9", City: "Boston"},
+		Confidence: 0.75,
+	}
+	address := &ParseResult{
+		Type:       "address",
+		Address:    &ParsedAddress{Number: "9", Street: "Box", City: "Boston"},
+		Confidence: 0.75,
+	}
+	higher := &ParseResult{
+		Type:       "address",
+		Address:    &ParsedAddress{Street: "Main"},
+		Confidence: 0.8,
+	}
+
+	tests := []struct {
+		name          string
+		better, worse *ParseResult
+	}{
+		{"Higher confidence wins", higher, full},
+		{"More components break a tie", full, fewer},
+		{"ZIP breaks a tie", withZIP, noZIP},
+		{"Address beats PO box", address, poBox},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !betterGuess(tt.better, tt.worse) {
+				t.Errorf("betterGuess(%+v, %+v) = false, want true", tt.better, tt.worse)
+			}
+			if betterGuess(tt.worse, tt.better) {
+				t.Errorf("betterGuess(%+v, %+v) = true, want false", tt.worse, tt.better)
+			}
+		})
+	}
+}
+
+func TestBestGuess(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		input    string
+		wantType string
+	}{
+		{"1005 N Gravenstein Hwy, Sebastopol, CA 95472", "address"},
+		{"PO Box 1234, Denver, CO 80201", "po_box"},
+		{"Mail: P.O. Box 12, Town ST 12345", "po_box"},
+		{"Mission St and Valencia St, San Francisco, CA", "intersection"},
+		{"", "none"},
+		{"123 Main\x00St", "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := p.BestGuess(tt.input)
+			if result == nil {
+				t.Fatal("BestGuess() returned nil")
+			}
+			if result.Type != tt.wantType {
+				t.Errorf("Type: got %q, want %q", result.Type, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestParseCandidates(t *testing.T) {
+	p := NewParser()
+
+	candidates := p.ParseCandidates("Main and Elm")
+	if len(candidates) < 2 {
+		t.Fatalf("got %d candidates, want at least 2", len(candidates))
+	}
+	types := map[string]bool{}
+	for i, c := range candidates {
+		types[c.Type] = true
+		if c.Confidence <= 0 {
+			t.Errorf("candidate %d: confidence %v, want > 0", i, c.Confidence)
+		}
+		if i > 0 && c.Confidence > candidates[i-1].Confidence {
+			t.Errorf("candidate %d scored %v above candidate %d (%v)", i, c.Confidence, i-1, candidates[i-1].Confidence)
+		}
+	}
+	if !types["intersection"] || !types["address"] {
+		t.Errorf("got types %v, want intersection and address", types)
+	}
+
+	if got := p.ParseCandidates(""); got != nil {
+		t.Errorf("invalid input: got %v, want nil", got)
+	}
+}
+
+func TestReturnEmptyOnNone(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{"Default nil address", false},
+		{"Empty address", true},
+	}
+
+	for _, tt := range tests {
+		enabled := tt.enabled
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParserWithOptions(ParseOptions{ReturnEmptyOnNone: enabled})
+
+			batch, err := p.ParseBatch(context.Background(), []string{"", "123 Main St"})
+			if err != nil {
+				t.Fatalf("ParseBatch() error = %v", err)
+			}
+			results := []*ParseResult{p.BestGuess(""), batch[0]}
+			for _, result := range results {
+				if result.Type != "none" {
+					t.Fatalf("Type: got %q, want none", result.Type)
+				}
+				if enabled && (result.Address == nil || !result.Address.IsEmpty()) {
+					t.Errorf("Address: got %+v, want empty", result.Address)
+				}
+				if !enabled && result.Address != nil {
+					t.Errorf("Address: got %+v, want nil", result.Address)
+				}
+			}
+
+			if batch[1].Type != "address" || batch[1].Address.Street != "Main" {
+				t.Errorf("parsed address: got %+v, want Main St", batch[1].Address)
+			}
+		})
+	}
+}
+
+func TestParseAddressCityNamedLikeState(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "New York with commas",
+			input:    "350 5th Ave, New York, NY 10001",
+			expected: ParsedAddress{Number: "350", Street: "5th", Type: "ave", City: "New York", State: "NY", ZIP: "10001"},
+		},
+		{
+			name:     "New York single line",
+			input:    "350 5th Ave New York NY 10001",
+			expected: ParsedAddress{Number: "350", Street: "5th", Type: "ave", City: "New York", State: "NY", ZIP: "10001"},
+		},
+		{
+			name:     "New York spelled out as the state",
+			input:    "1 Main St, New York, New York 10001",
+			expected: ParsedAddress{Number: "1", Street: "Main", Type: "st", City: "New York", State: "NY", ZIP: "10001"},
+		},
+		{
+			name:     "Washington DC",
+			input:    "1600 Pennsylvania Ave NW, Washington, DC 20500",
+			expected: ParsedAddress{Number: "1600", Street: "Pennsylvania", Type: "ave", Suffix: "NW", City: "Washington", State: "DC", ZIP: "20500"},
+		},
+		{
+			name:     "Kansas City MO",
+			input:    "100 Main St, Kansas City, MO 64105",
+			expected: ParsedAddress{Number: "100", Street: "Main", Type: "st", City: "Kansas City", State: "MO", ZIP: "64105"},
+		},
+		{
+			name:     "Kansas City KS",
+			input:    "100 Main St Kansas City KS",
+			expected: ParsedAddress{Number: "100", Street: "Main", Type: "st", City: "Kansas City", State: "KS"},
+		},
+		{
+			name:     "Kansas City, Kansas",
+			input:    "1 Main St, Kansas City, Kansas",
+			expected: ParsedAddress{Number: "1", Street: "Main", Type: "st", City: "Kansas City", State: "KS"},
+		},
+		{
+			name:     "Lone state name after the street is the city",
+			input:    "100 Main St, Washington",
+			expected: ParsedAddress{Number: "100", Street: "Main", Type: "st", City: "Washington"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressSpelledStateSingleLine(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Illinois",
+			input:    "123 Main Street Springfield Illinois 62704",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62704"},
+		},
+		{
+			name:     "California",
+			input:    "123 Main St Sacramento California 95814",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Sacramento", State: "CA", ZIP: "95814"},
+		},
+		{
+			name:     "New Hampshire",
+			input:    "10 Elm St Concord New Hampshire 03301",
+			expected: ParsedAddress{Number: "10", Street: "Elm", Type: "st", City: "Concord", State: "NH", ZIP: "03301"},
+		},
+		{
+			name:     "District of Columbia",
+			input:    "1600 Pennsylvania Ave NW Washington District of Columbia 20500",
+			expected: ParsedAddress{Number: "1600", Street: "Pennsylvania", Type: "ave", Suffix: "NW", City: "Washington", State: "DC", ZIP: "20500"},
+		},
+		{
+			name:     "Two-word city",
+			input:    "123 Main St St Louis Missouri",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "St Louis", State: "MO"},
+		},
+		{
+			name:     "State name with no city is not the state",
+			input:    "123 Main St Washington",
+			expected: ParsedAddress{Number: "123", Street: "Main St Washington"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseMultiple(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name      string
+		text      string
+		wantTypes []string
+		wantZIPs  []string
+	}{
+		{
+			name:      "One address per line",
+			text:      "1005 N Gravenstein Hwy, Sebastopol, CA 95472\n123 Main St, Springfield, IL 62701\n",
+			wantTypes: []string{"address", "address"},
+			wantZIPs:  []string{"95472", "62701"},
+		},
+		{
+			name:      "Semicolons with empty fragments",
+			text:      "123 Main St, Springfield, IL 62701; ; PO Box 1234, Denver, CO 80201;",
+			wantTypes: []string{"address", "po_box"},
+			wantZIPs:  []string{"62701", "80201"},
+		},
+		{
+			name:      "Multi-line addresses",
+			text:      "1005 N Gravenstein Hwy\r\nSebastopol, CA 95472\r\n\r\n123 Main St\r\nSpringfield, IL 62701\r\nPO Box 99\r\nAustin, TX 78701",
+			wantTypes: []string{"address", "address", "po_box"},
+			wantZIPs:  []string{"95472", "62701", "78701"},
+		},
+		{
+			name:      "City, state and ZIP on the third line",
+			text:      "123 Main St\nApt 4\nPortland OR 97201\n500 Oak Ave\nSalem OR 97301",
+			wantTypes: []string{"address", "address"},
+			wantZIPs:  []string{"97201", "97301"},
+		},
+		{
+			name:      "ZIP on its own line",
+			text:      "123 Main St\nPortland, OR\n97201-1234\n500 Oak Ave\nSalem\nOR 97301",
+			wantTypes: []string{"address", "address"},
+			wantZIPs:  []string{"97201", "97301"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := p.ParseMultiple(tt.text)
+			if len(results) != len(tt.wantTypes) {
+				t.Fatalf("Results: got %d, want %d", len(results), len(tt.wantTypes))
+			}
+			for i, r := range results {
+				if r.Type != tt.wantTypes[i] {
+					t.Errorf("Result %d type: got %q, want %q", i, r.Type, tt.wantTypes[i])
+				}
+				if r.Address == nil || r.Address.ZIP != tt.wantZIPs[i] {
+					t.Errorf("Result %d ZIP: got %+v, want %q", i, r.Address, tt.wantZIPs[i])
+				}
+			}
+		})
+	}
+
+	if results := p.ParseMultiple(" ;\n\n; "); len(results) != 0 {
+		t.Errorf("Empty text: got %d results, want 0", len(results))
+	}
+}
+
+func TestParseBatchPage(t *testing.T) {
+	p := NewParser()
+	addresses := []string{
+		"123 Main St, Springfield, IL 62701",
+		"500 Oak Ave, Salem, OR 97301",
+		"PO Box 99, Austin, TX 78701",
+		"",
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		offset    int
+		wantTypes []string
+	}{
+		{name: "No limit", wantTypes: []string{"address", "address", "po_box", "none"}},
+		{name: "Limit", limit: 2, wantTypes: []string{"address", "address"}},
+		{name: "Offset", offset: 2, wantTypes: []string{"po_box", "none"}},
+		{name: "Limit and offset", limit: 1, offset: 2, wantTypes: []string{"po_box"}},
+		{name: "Limit past the end", limit: 10, offset: 3, wantTypes: []string{"none"}},
+		{name: "Offset past the end", limit: 2, offset: 4, wantTypes: []string{}},
+		{name: "Offset far past the end", offset: 100, wantTypes: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := p.ParseBatchPage(context.Background(), addresses, tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("ParseBatchPage() failed: %v", err)
+			}
+			if page.Total != len(addresses) || page.Offset != tt.offset {
+				t.Errorf("got total %d offset %d, want %d and %d", page.Total, page.Offset, len(addresses), tt.offset)
+			}
+			if page.Results == nil || len(page.Results) != len(tt.wantTypes) {
+				t.Fatalf("Results: got %v, want %d", page.Results, len(tt.wantTypes))
+			}
+			for i, r := range page.Results {
+				if r.Type != tt.wantTypes[i] {
+					t.Errorf("Result %d type: got %q, want %q", i, r.Type, tt.wantTypes[i])
+				}
+			}
+		})
+	}
+
+	for _, bad := range [][2]int{{-1, 0}, {0, -1}} {
+		if _, err := p.ParseBatchPage(context.Background(), addresses, bad[0], bad[1]); !errors.Is(err, ErrInvalidPage) {
+			t.Errorf("limit %d offset %d: got error %v, want ErrInvalidPage", bad[0], bad[1], err)
+		}
+	}
+
+	page, err := p.ParseMultiplePage("123 Main St, Springfield, IL 62701; PO Box 99, Austin, TX 78701", 1, 1)
+	if err != nil {
+		t.Fatalf("ParseMultiplePage() failed: %v", err)
+	}
+	if page.Total != 2 || len(page.Results) != 1 || page.Results[0].Type != "po_box" {
+		t.Errorf("ParseMultiplePage: got total %d and %v, want 2 and one po_box", page.Total, page.Results)
+	}
+}
+
+func TestParseAddressRuralRoute(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Rt with box is a rural route",
+			input:    "Rt 9 Box 12",
+			expected: ParsedAddress{Street: "Rural Route 9", SecUnitType: "Box", SecUnitNum: "12"},
+		},
+		{
+			name:     "RR with locality",
+			input:    "RR 2 Box 152, Anytown, VT 05401",
+			expected: ParsedAddress{Street: "Rural Route 2", SecUnitType: "Box", SecUnitNum: "152", City: "Anytown", State: "VT", ZIP: "05401"},
+		},
+		{
+			name:     "Rt without box is a highway",
+			input:    "123 Rt 9 Town ST",
+			expected: ParsedAddress{Number: "123", Street: "Route 9", Type: "rte", City: "Town", RouteNumber: "9"},
+		},
+		{
+			name:     "Rt highway with a single-line locality",
+			input:    "123 Rt 9 Kingston NY",
+			expected: ParsedAddress{Number: "123", Street: "Route 9", Type: "rte", City: "Kingston", State: "NY", RouteNumber: "9"},
+		},
+		{
+			name:     "Street type after a route number is not a state",
+			input:    "123 Route 9 Service Rd",
+			expected: ParsedAddress{Number: "123", Street: "Route 9 Service", Type: "rd"},
+		},
+		{
+			name:     "Rt highway with locality",
+			input:    "123 Rt 9, Kingston, NY",
+			expected: ParsedAddress{Number: "123", Street: "Route 9", Type: "rte", City: "Kingston", State: "NY", RouteNumber: "9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseUSPSLines(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		line1    string
+		line2    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Apartment on line 2",
+			line1:    "123 Main St",
+			line2:    "Apt 4B",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "4B"},
+		},
+		{
+			name:     "Hash unit with locality on line 1",
+			line1:    "1005 N Gravenstein Hwy, Sebastopol, CA 95472",
+			line2:    "#500",
+			expected: ParsedAddress{Number: "1005", Prefix: "N", Street: "Gravenstein", Type: "hwy", SecUnitType: "#", SecUnitNum: "500", City: "Sebastopol", State: "CA", ZIP: "95472"},
+		},
+		{
+			name:     "Bare unit number",
+			line1:    "123 Main St",
+			line2:    "4B",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitNum: "4B"},
+		},
+		{
+			name:     "Unit word",
+			line1:    "123 Main St",
+			line2:    "Rear",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Rear"},
+		},
+		{
+			name:     "Line 2 unit replaces line 1 unit",
+			line1:    "123 Main St Apt 1",
+			line2:    "Suite 200",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Suite", SecUnitNum: "200"},
+		},
+		{
+			name:     "Empty line 2",
+			line1:    "123 Main St Apt 1",
+			line2:    "",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseUSPSLines(tt.line1, tt.line2)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseStreetLine(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "State name as street",
+			input:    "100 State St",
+			expected: ParsedAddress{Number: "100", Street: "State", Type: "st"},
+		},
+		{
+			name:     "Quadrant not a state",
+			input:    "100 State St NE",
+			expected: ParsedAddress{Number: "100", Street: "State", Type: "st", Suffix: "NE"},
+		},
+		{
+			name:     "Street type that is a state code",
+			input:    "15 Oak CT",
+			expected: ParsedAddress{Number: "15", Street: "Oak", Type: "ct"},
+		},
+		{
+			name:  "Prefix and unit",
+			input: "123 N Main St Apt 4B",
+			expected: ParsedAddress{
+				Number:      "123",
+				Prefix:      "N",
+				Street:      "Main",
+				Type:        "st",
+				SecUnitType: "Apt",
+				SecUnitNum:  "4B",
+			},
+		},
+		{
+			name:  "Unit after a comma",
+			input: "200 Washington Ave, Suite 5",
+			expected: ParsedAddress{
+				Number:      "200",
+				Street:      "Washington",
+				Type:        "ave",
+				SecUnitType: "Suite",
+				SecUnitNum:  "5",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseStreetLine(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseLocationWarnings(t *testing.T) {
+	p := NewParser()
+
+	result, err := p.ParseLocation("100 Park")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if len(result.Warnings) == 0 {
+		t.Fatal("Warnings: got none, want an ambiguity warning")
+	}
+	if !strings.Contains(result.Warnings[0], `"Park"`) || !strings.Contains(result.Warnings[0], "street name") {
+		t.Errorf("Warnings: got %q, want Park noted as a possible street name", result.Warnings)
+	}
+
+	result, err = p.ParseLocation("100 Park Ave, New York, NY 10001")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Warnings for an unambiguous address: got %q, want none", result.Warnings)
+	}
+}
+
+func TestParseLocationDirectionalOnly(t *testing.T) {
+	p := NewParser()
+
+	result, err := p.ParseLocation("100 NE Portland OR")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+
+	expected := ParsedAddress{Number: "100", Suffix: "NE", City: "Portland", State: "OR"}
+	if result.Address == nil || *result.Address != expected {
+		t.Fatalf("got %+v, want %+v", result.Address, expected)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0] != "no street name" {
+		t.Errorf("Warnings: got %q, want [\"no street name\"]", result.Warnings)
+	}
+}
+
+func TestParseLocationPlaceSegment(t *testing.T) {
+	p := NewParser()
+
+	result, err := p.ParseLocation("123 Main St, Barnes & Noble Plaza, Springfield, IL 62701")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Type != "address" {
+		t.Fatalf("Type: got %q, want address", result.Type)
+	}
+
+	expected := ParsedAddress{BuildingName: "Barnes & Noble Plaza", Number: "123", Street: "Main", Type: "st",
+		City: "Springfield", State: "IL", ZIP: "62701"}
+	if *result.Address != expected {
+		t.Errorf("got %+v, want %+v", *result.Address, expected)
+	}
+}
+
+func TestParseLocationBareBoxAndGeneralDelivery(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		wantType string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Bare box with city and state",
+			input:    "Box 45, Town, TX",
+			wantType: "po_box",
+			expected: ParsedAddress{SecUnitType: "Box", SecUnitNum: "45", City: "Town", State: "TX"},
+		},
+		{
+			name:     "General Delivery",
+			input:    "General Delivery, Juneau, AK 99801",
+			wantType: "po_box",
+			expected: ParsedAddress{SecUnitType: GeneralDelivery, City: "Juneau", State: "AK", ZIP: "99801"},
+		},
+		{
+			name:     "Bare box without a state",
+			input:    "Box 45",
+			wantType: "address",
+			expected: ParsedAddress{Street: "Box 45"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			if result.Type != tt.wantType {
+				t.Errorf("Type: got %q, want %q", result.Type, tt.wantType)
+			}
+			if result.Address == nil || *result.Address != tt.expected {
+				t.Errorf("got %+v, want %+v", result.Address, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressNumberRange(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Hyphen", "100-200 Main St"},
+		{"Word to", "100 to 200 Main St"},
+		{"En dash", "100–200 Main St"},
+		{"Spaced em dash", "100 — 200 Main St"},
+	}
+
+	expected := ParsedAddress{Number: "100-200", NumberLow: "100", NumberHigh: "200", Street: "Main", Type: "st"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != expected {
+				t.Errorf("got %+v, want %+v", *result, expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressGridAndFractionNumbers(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Grid coordinates",
+			input:    "N6W23001 Bluemound Rd, Waukesha WI",
+			expected: ParsedAddress{Number: "N6W23001", Street: "Bluemound", Type: "rd", City: "Waukesha", State: "WI"},
+		},
+		{
+			name:     "Grid coordinates west first",
+			input:    "W180N8130 Town Hall Rd, Menomonee Falls, WI 53051",
+			expected: ParsedAddress{Number: "W180N8130", Street: "Town Hall", Type: "rd", City: "Menomonee Falls", State: "WI", ZIP: "53051"},
+		},
+		{
+			name:     "Lower-case grid coordinates",
+			input:    "n6w23001 Bluemound Rd Waukesha WI 53186",
+			expected: ParsedAddress{Number: "N6W23001", Street: "Bluemound", Type: "rd", City: "Waukesha", State: "WI", ZIP: "53186"},
+		},
+		{
+			name:     "Fraction after the number",
+			input:    "123 1/2 Main St, Springfield IL",
+			expected: ParsedAddress{Number: "123 1/2", Street: "Main", Type: "st", City: "Springfield", State: "IL"},
+		},
+		{
+			name:     "Fraction with a unit",
+			input:    "123 1/2 Main St Apt 2, Springfield IL",
+			expected: ParsedAddress{Number: "123 1/2", Street: "Main", Type: "st", SecUnitType: "Apt", SecUnitNum: "2", City: "Springfield", State: "IL"},
+		},
+		{
+			name:     "Fraction after the street is a unit",
+			input:    "123 Main St 1/2",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", SecUnitNum: "1/2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+			if errs := result.Validate(); errs != nil {
+				t.Errorf("Validate(): got %v, want none", errs)
+			}
+		})
+	}
+}
+
+func TestParseAddressQueensNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		queens   bool
+		expected ParsedAddress
+	}{
+		{"Ascending hyphen is a range", "100-110 Main St", false, ParsedAddress{Number: "100-110", NumberLow: "100", NumberHigh: "110", Street: "Main", Type: "st"}},
+		{"Descending hyphen is one number", "123-45 Queens Blvd", false, ParsedAddress{Number: "123-45", Street: "Queens", Type: "blvd"}},
+		{"Ascending hyphen is one Queens number", "21-35 Steinway St", true, ParsedAddress{Number: "21-35", Street: "Steinway", Type: "st"}},
+		{"Descending hyphen is one Queens number", "123-45 Queens Blvd", true, ParsedAddress{Number: "123-45", Street: "Queens", Type: "blvd"}},
+		{"Word to is still a range", "100 to 110 Main St", true, ParsedAddress{Number: "100-110", NumberLow: "100", NumberHigh: "110", Street: "Main", Type: "st"}},
+		{"Spaced hyphen is still a range", "100 - 110 Main St", true, ParsedAddress{Number: "100-110", NumberLow: "100", NumberHigh: "110", Street: "Main", Type: "st"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParserWithOptions(ParseOptions{QueensNumbers: tt.queens})
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressNumberDesignator(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{"No. with period", "No. 123 Main St", ParsedAddress{Number: "123", Street: "Main", Type: "st"}},
+		{"Nr without period", "Nr 45 Oak Ave", ParsedAddress{Number: "45", Street: "Oak", Type: "ave"}},
+		{"No run together", "no.7 Elm St, Springfield, IL", ParsedAddress{Number: "7", Street: "Elm", Type: "st", City: "Springfield", State: "IL"}},
+		{"No as a street word", "123 No Name Rd", ParsedAddress{Number: "123", Street: "No Name", Type: "rd"}},
+		{"No before an ordinal", "No 5th Ave", ParsedAddress{Street: "No 5th", Type: "ave"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseLocationBarcodeArtifact(t *testing.T) {
+	p := NewParser()
+
+	result, err := p.ParseLocation("123456789012 123 Main St Portland OR 97201")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+
+	expected := ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Portland", State: "OR", ZIP: "97201"}
+	if result.Address == nil || *result.Address != expected {
+		t.Fatalf("got %+v, want %+v", result.Address, expected)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "barcode") {
+		t.Errorf("Warnings: got %q, want a barcode artifact warning", result.Warnings)
+	}
+
+	// A ZIP+4 written without its hyphen is not an artifact
+	result, err = p.ParseLocation("123 Main St Portland OR 972011234")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Warnings: got %q, want none", result.Warnings)
+	}
+}
+
+func TestParseAddressAcronyms(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{"FDR", "100 FDR Drive", ParsedAddress{Number: "100", Street: "FDR", Type: "dr"}},
+		{"JFK lower case", "200 jfk blvd", ParsedAddress{Number: "200", Street: "JFK", Type: "blvd"}},
+		{"MLK Jr", "300 MLK JR BLVD", ParsedAddress{Number: "300", Street: "MLK Jr", Type: "blvd"}},
+		{"US Highway", "1005 US Highway 101", ParsedAddress{Number: "1005", Street: "US 101", Type: "hwy", RouteNumber: "101"}},
+		{"All caps street", "400 MAIN STREET", ParsedAddress{Number: "400", Street: "Main", Type: "st"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressConnectors(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{"Ponce de Leon", "123 Ponce De Leon Blvd", ParsedAddress{Number: "123", Street: "Ponce de Leon", Type: "blvd"}},
+		{"Isle of Palms", "1 Palm Blvd, ISLE OF PALMS, SC", ParsedAddress{Number: "1", Street: "Palm", Type: "blvd", City: "Isle of Palms", State: "SC"}},
+		{"Leading connector", "5 De La Cruz Blvd", ParsedAddress{Number: "5", Street: "De la Cruz", Type: "blvd"}},
+		{"Of the", "1 avenue of the americas", ParsedAddress{Number: "1", Street: "Avenue of the Americas"}},
+		{"Von", "8 Ludwig Von Mises Way", ParsedAddress{Number: "8", Street: "Ludwig von Mises", Type: "way"}},
+		{"Article without a connector", "1 Main St, North Las Vegas, NV", ParsedAddress{Number: "1", Street: "Main", Type: "st", City: "North Las Vegas", State: "NV"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressCountry(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "USA after a comma",
+			input:    "123 Main St, Springfield, IL 62704, USA",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62704", Country: "US"},
+		},
+		{
+			name:     "Spelled out after the ZIP",
+			input:    "123 Main St, Springfield, IL 62704 United States",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62704", Country: "US"},
+		},
+		{
+			name:     "Dotted code",
+			input:    "123 Main St, Springfield, IL 62704, U.S.A.",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62704", Country: "US"},
+		},
+		{
+			name:     "Canada",
+			input:    "500 Main St, Buffalo, NY 14202, Canada",
+			expected: ParsedAddress{Number: "500", Street: "Main", Type: "st", City: "Buffalo", State: "NY", ZIP: "14202", Country: "CA"},
+		},
+		{
+			name:     "Street named after a country",
+			input:    "100 Canada Ave",
+			expected: ParsedAddress{Number: "100", Street: "Canada", Type: "ave"},
+		},
+		{
+			name:     "CA is the state",
+			input:    "1005 Gravenstein Hwy N, Sebastopol, CA 95472",
+			expected: ParsedAddress{Number: "1005", Street: "Gravenstein", Type: "hwy", Suffix: "N", City: "Sebastopol", State: "CA", ZIP: "95472"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddressCounty(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{
+			name:     "Name then County",
+			input:    "123 Main St, Sonoma County, Sebastopol CA",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Sebastopol", County: "Sonoma", State: "CA"},
+		},
+		{
+			name:     "County of name",
+			input:    "123 Main St, County of Sonoma, Sebastopol, CA 95472",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Sebastopol", County: "Sonoma", State: "CA", ZIP: "95472"},
+		},
+		{
+			name:     "County after the city",
+			input:    "123 Main St, Sebastopol, sonoma county, CA 95472",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Sebastopol", County: "Sonoma", State: "CA", ZIP: "95472"},
+		},
+		{
+			name:     "County right before the state",
+			input:    "123 Main St, Orange County, CA",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", County: "Orange", State: "CA"},
+		},
+		{
+			name:     "County right before the state and ZIP",
+			input:    "123 Main St, Sonoma County, CA 95472",
+			expected: ParsedAddress{Number: "123", Street: "Main", Type: "st", County: "Sonoma", State: "CA", ZIP: "95472"},
+		},
+		{
+			name:     "County road is the street",
+			input:    "12 County Line Rd, Springfield, IL",
+			expected: ParsedAddress{Number: "12", Street: "County Line", Type: "rd", City: "Springfield", State: "IL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("PO box", func(t *testing.T) {
+		result := p.ParsePoAddress("PO Box 5, Sonoma County, Sebastopol CA 95472")
+		if result.County != "Sonoma" || result.City != "Sebastopol" {
+			t.Errorf("got %+v, want county Sonoma, city Sebastopol", *result)
+		}
+	})
+}
+
+func TestParseLocationUSASuffix(t *testing.T) {
+	p := NewParser()
+
+	suffixes := []string{", USA", ", U.S.A.", " U.S.A", ", US", ", United States", ", United States of America", " united states of america"}
+	for _, suffix := range suffixes {
+		t.Run(suffix, func(t *testing.T) {
+			result, err := p.ParseLocation("123 Main St, Springfield, IL 62704" + suffix)
+			if err != nil {
+				t.Fatalf("ParseLocation() error = %v", err)
+			}
+			want := ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62704", Country: "US"}
+			if result.Address == nil || *result.Address != want {
+				t.Errorf("address: got %+v, want %+v", result.Address, want)
+			}
+
+			result, err = p.ParseLocation("PO Box 12, Austin, TX 78701" + suffix)
+			if err != nil {
+				t.Fatalf("ParseLocation() error = %v", err)
+			}
+			if result.Type != "po_box" || result.Address.City != "Austin" || result.Address.Country != "US" {
+				t.Errorf("PO box: got %s %+v, want Austin, US", result.Type, result.Address)
+			}
+
+			result, err = p.ParseLocation("Main St and Elm St, Springfield, IL" + suffix)
+			if err != nil {
+				t.Fatalf("ParseLocation() error = %v", err)
+			}
+			wantIntersection := ParsedIntersection{Street1: "Main", Type1: "st", Street2: "Elm", Type2: "st", City: "Springfield", State: "IL", Country: "US"}
+			if result.Intersection == nil || *result.Intersection != wantIntersection {
+				t.Errorf("intersection: got %+v, want %+v", result.Intersection, wantIntersection)
+			}
+		})
+	}
+}
+
+func TestParseAddressHighway(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedAddress
+	}{
+		{"Interstate", "I-80 Exit 12", ParsedAddress{Street: "I-80", Type: "hwy", SecUnitType: "Exit", SecUnitNum: "12", RouteNumber: "80"}},
+		{"Interstate spelled out", "200 Interstate 5 N", ParsedAddress{Number: "200", Street: "I-5", Type: "hwy", Suffix: "N", RouteNumber: "5"}},
+		{"US route", "1005 US Route 101, Sebastopol, CA", ParsedAddress{Number: "1005", Street: "US 101", Type: "hwy", City: "Sebastopol", State: "CA", RouteNumber: "101"}},
+		{"US route hyphenated", "4200 US-1", ParsedAddress{Number: "4200", Street: "US 1", Type: "hwy", RouteNumber: "1"}},
+		{"State route", "SR-52", ParsedAddress{Street: "SR 52", Type: "hwy", RouteNumber: "52"}},
+		{"State route spelled out", "3100 State Route 9A", ParsedAddress{Number: "3100", Street: "SR 9A", Type: "hwy", RouteNumber: "9A"}},
+		{"Street named I", "100 I St", ParsedAddress{Number: "100", Street: "I", Type: "st"}},
+		{"Highway", "1005 Highway 12", ParsedAddress{Number: "1005", Street: "Highway 12", Type: "hwy", RouteNumber: "12"}},
+		{"Highway abbreviated with suffix", "12 Hwy 1 N", ParsedAddress{Number: "12", Street: "Highway 1", Type: "hwy", Suffix: "N", RouteNumber: "1"}},
+		{"Route", "Route 66", ParsedAddress{Street: "Route 66", Type: "rte", RouteNumber: "66"}},
+		{"Route with locality", "200 Rte 66, Tulsa, OK", ParsedAddress{Number: "200", Street: "Route 66", Type: "rte", City: "Tulsa", State: "OK", RouteNumber: "66"}},
+		{"Route then street type is a street", "100 Route 9 Rd", ParsedAddress{Number: "100", Street: "Route 9", Type: "rd"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.ParseAddress(tt.input)
+			if *result != tt.expected {
+				t.Errorf("got %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseLocationVacantLand(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name       string
+		input      string
+		wantParcel string
+		wantReason string
+	}{
+		{"Vacant lot with parcel", "Vacant Lot, Parcel 123-45-678, County Rd 9", "123-45-678", "vacant land"},
+		{"Parcel only", "APN: 0123.456.789, Bend, OR", "0123.456.789", "parcel with no house number"},
+		{"Dwelling", "123 Main St, Springfield, IL 62701", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.ParseLocation(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			if result.Address == nil || result.Address.Parcel != tt.wantParcel {
+				t.Errorf("Parcel: got %+v, want %q", result.Address, tt.wantParcel)
+			}
+			if result.Undeliverable != tt.wantReason {
+				t.Errorf("Undeliverable: got %q, want %q", result.Undeliverable, tt.wantReason)
+			}
+		})
 	}
 }
@@ -0,0 +1,93 @@
+package parser
+
+// Fields each parser looks for, by JSON name. A field missing from a list is
+// never attempted by that parser.
+var (
+	addressFields = []string{
+		"attention", "care_of", "building_name", "number", "number_low", "number_high", "prefix", "street", "type", "suffix",
+		"sec_unit_type", "sec_unit_num", "sec_unit_type2", "sec_unit_num2",
+		"city", "county", "state", "zip", "plus4", "country", "parcel", "route_number",
+	}
+	poBoxFields = []string{
+		"attention", "care_of", "sec_unit_type", "sec_unit_num", "city", "county", "state", "zip", "plus4", "country",
+	}
+	intersectionFields = []string{
+		"prefix1", "street1", "type1", "suffix1",
+		"prefix2", "street2", "type2", "suffix2",
+		"city", "state", "zip", "country",
+	}
+)
+
+// setPresence fills r.Presence when ParseOptions.ReportPresence is set
+func (p *Parser) setPresence(r *ParseResult) {
+	if !p.options.ReportPresence || r == nil {
+		return
+	}
+	switch r.Type {
+	case "address":
+		r.Presence = presence(addressValues(r.Address), addressFields)
+	case "po_box":
+		r.Presence = presence(addressValues(r.Address), poBoxFields)
+	case "intersection":
+		r.Presence = presence(intersectionValues(r.Intersection), intersectionFields)
+	}
+}
+
+// presence reports, for each attempted field, whether a value was found
+func presence(values map[string]string, attempted []string) map[string]bool {
+	m := make(map[string]bool, len(attempted))
+	for _, f := range attempted {
+		m[f] = values[f] != ""
+	}
+	return m
+}
+
+func addressValues(a *ParsedAddress) map[string]string {
+	if a == nil {
+		return nil
+	}
+	return map[string]string{
+		"attention":      a.Attention,
+		"care_of":        a.CareOf,
+		"building_name":  a.BuildingName,
+		"number":         a.Number,
+		"number_low":     a.NumberLow,
+		"number_high":    a.NumberHigh,
+		"prefix":         a.Prefix,
+		"street":         a.Street,
+		"type":           a.Type,
+		"suffix":         a.Suffix,
+		"sec_unit_type":  a.SecUnitType,
+		"sec_unit_num":   a.SecUnitNum,
+		"sec_unit_type2": a.SecUnitType2,
+		"sec_unit_num2":  a.SecUnitNum2,
+		"city":           a.City,
+		"county":         a.County,
+		"state":          a.State,
+		"zip":            a.ZIP,
+		"plus4":          a.Plus4,
+		"country":        a.Country,
+		"parcel":         a.Parcel,
+		"route_number":   a.RouteNumber,
+	}
+}
+
+func intersectionValues(i *ParsedIntersection) map[string]string {
+	if i == nil {
+		return nil
+	}
+	return map[string]string{
+		"prefix1": i.Prefix1,
+		"street1": i.Street1,
+		"type1":   i.Type1,
+		"suffix1": i.Suffix1,
+		"prefix2": i.Prefix2,
+		"street2": i.Street2,
+		"type2":   i.Type2,
+		"suffix2": i.Suffix2,
+		"city":    i.City,
+		"state":   i.State,
+		"zip":     i.ZIP,
+		"country": i.Country,
+	}
+}
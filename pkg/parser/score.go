@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"math"
+	"strings"
+)
+
+// score rates a candidate parse from 0 (nothing useful) to 1 (every major
+// component found). ParseLocation uses it to pick between parsers.
+func (p *Parser) score(r *ParseResult) float64 {
+	var s float64
+	switch {
+	case r.Intersection != nil:
+		s = p.scoreIntersection(r.Intersection)
+	case r.Address != nil && r.Type == "po_box":
+		s = scorePoBox(r.Address)
+	case r.Address != nil:
+		s = scoreAddress(r.Address)
+	}
+	return math.Round(math.Min(s, 1)*100) / 100
+}
+
+// scoreAddress weights the components of a street address
+func scoreAddress(a *ParsedAddress) float64 {
+	var s float64
+	if a.Number != "" {
+		s += 0.25
+	}
+	if a.Street != "" {
+		s += 0.25
+	}
+	if a.Type != "" {
+		s += 0.15
+	}
+	if a.Prefix != "" || a.Suffix != "" || a.SecUnitType != "" {
+		s += 0.05
+	}
+	return s + scoreLocality(a.City, a.State, a.ZIP)
+}
+
+// scorePoBox weights a PO box, where the box number (or General Delivery)
+// stands in for the street line
+func scorePoBox(a *ParsedAddress) float64 {
+	var s float64
+	if a.SecUnitType != "" && (a.SecUnitNum != "" || a.SecUnitType == GeneralDelivery) {
+		s += 0.7
+	}
+	return s + scoreLocality(a.City, a.State, a.ZIP)
+}
+
+// scoreIntersection weights an intersection. Both streets are required, and
+// a house number on the first street suggests the corner marker was really
+// part of a street name ("10 Bread and Butter Ln"). A numbered street line
+// after it means the marker was in a building name ("Barnes & Noble, 123
+// Main St"), which rules the intersection out.
+func (p *Parser) scoreIntersection(i *ParsedIntersection) float64 {
+	if i.Street1 == "" || i.Street2 == "" {
+		return 0
+	}
+	if p.hasStreetLine(i.Street2) || p.hasStreetLine(i.City) {
+		return 0
+	}
+	s := 0.6
+	if i.Type1 != "" {
+		s += 0.05
+	}
+	if i.Type2 != "" {
+		s += 0.05
+	}
+	s += scoreLocality(i.City, i.State, i.ZIP)
+
+	if first := strings.Fields(i.Street1); len(first) > 0 && p.patterns.number.MatchString(first[0]) && len(first) > 1 {
+		s /= 2
+	}
+	return s
+}
+
+// hasStreetLine reports whether a comma segment of field starts with a
+// house number followed by a street name
+func (p *Parser) hasStreetLine(field string) bool {
+	for _, segment := range strings.Split(field, ",") {
+		if words := strings.Fields(segment); len(words) > 1 && p.patterns.number.MatchString(words[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// scoreLocality weights the city, state and ZIP shared by every result type
+func scoreLocality(city, state, zip string) float64 {
+	var s float64
+	if city != "" {
+		s += 0.1
+	}
+	if state != "" {
+		s += 0.1
+	}
+	if zip != "" {
+		s += 0.1
+	}
+	return s
+}
@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestInputValidation tests input validation and sanitization
@@ -54,6 +56,23 @@ func TestInputValidation(t *testing.T) {
 	}
 }
 
+// TestErrorCode tests the stable codes reported for parse errors
+func TestErrorCode(t *testing.T) {
+	p := NewParser()
+
+	_, err := p.ParseLocation(strings.Repeat("A", MaxInputLength+1))
+	if got := ErrorCode(err); got != "INPUT_TOO_LONG" {
+		t.Errorf("too long: got %q, want INPUT_TOO_LONG", got)
+	}
+	_, err = p.ParseLocation("")
+	if got := ErrorCode(err); got != "INPUT_EMPTY" {
+		t.Errorf("empty: got %q, want INPUT_EMPTY", got)
+	}
+	if got := ErrorCode(errors.New("something else")); got != "PARSE_ERROR" {
+		t.Errorf("other error: got %q, want PARSE_ERROR", got)
+	}
+}
+
 // TestSanitization tests input sanitization
 func TestSanitization(t *testing.T) {
 	tests := []struct {
@@ -81,6 +100,76 @@ func TestSanitization(t *testing.T) {
 			input:    "123 Main\x00St",
 			expected: "123 MainSt",
 		},
+		{
+			name:     "Unit hash is kept",
+			input:    "123 Main St #4B",
+			expected: "123 Main St #4B",
+		},
+		{
+			name:     "Intersection symbols are kept",
+			input:    "Mission St & Valencia St / 16th St",
+			expected: "Mission St & Valencia St / 16th St",
+		},
+		{
+			name:     "Full-width comma",
+			input:    "Portland， OR 97201",
+			expected: "Portland, OR 97201",
+		},
+		{
+			name:     "Full-width digits",
+			input:    "Portland, OR ９７２０１",
+			expected: "Portland, OR 97201",
+		},
+		{
+			name:     "Middot separator",
+			input:    "123 Main St · Portland · OR",
+			expected: "123 Main St , Portland , OR",
+		},
+		{
+			name:     "Middot inside a word is kept",
+			input:    "12 Carrer del Paral·lel",
+			expected: "12 Carrer del Paral·lel",
+		},
+		{
+			name:     "Double-quoted CSV field",
+			input:    `"123 Main St, Portland, OR 97201"`,
+			expected: "123 Main St, Portland, OR 97201",
+		},
+		{
+			name:     "Single-quoted with inner whitespace",
+			input:    "' 123 Main St '",
+			expected: "123 Main St",
+		},
+		{
+			name:     "Apostrophe is kept",
+			input:    "123 O'Brien St",
+			expected: "123 O'Brien St",
+		},
+		{
+			name:     "Quoted address with apostrophe",
+			input:    `"123 O'Brien St"`,
+			expected: "123 O'Brien St",
+		},
+		{
+			name:     "Unmatched quote is kept",
+			input:    `"123 Main St`,
+			expected: `"123 Main St`,
+		},
+		{
+			name:     "Emoji removed",
+			input:    "123 Main St 🏠 Sebastopol CA",
+			expected: "123 Main St Sebastopol CA",
+		},
+		{
+			name:     "Emoji sequences removed without joining words",
+			input:    "🇺🇸 123 Main St👨‍👩‍👧Sebastopol, CA ❤️",
+			expected: "123 Main St Sebastopol, CA",
+		},
+		{
+			name:     "Symbols other than emoji kept",
+			input:    "№ 5 Main St",
+			expected: "№ 5 Main St",
+		},
 		{
 			name:     "Extremely long address",
 			input:    strings.Repeat("A", MaxAddressLength+100),
@@ -98,6 +187,84 @@ func TestSanitization(t *testing.T) {
 	}
 }
 
+// TestUnusualSeparators parses addresses written with full-width commas and
+// middots
+func TestUnusualSeparators(t *testing.T) {
+	p := NewParser()
+
+	for _, input := range []string{
+		"123 Main St， Portland， OR 97201",
+		"123 Main St·Portland·OR 97201",
+		"123 Main St · Portland · OR ９７２０１",
+	} {
+		t.Run(input, func(t *testing.T) {
+			result, err := p.ParseLocation(input)
+			if err != nil {
+				t.Fatalf("ParseLocation() failed: %v", err)
+			}
+			want := ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Portland", State: "OR", ZIP: "97201"}
+			if result.Address == nil || *result.Address != want {
+				t.Errorf("got %+v, want %+v", result.Address, want)
+			}
+		})
+	}
+}
+
+// TestStripSymbols tests the optional emoji and non-printable stripping
+func TestStripSymbols(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Emoji removed",
+			input:    "123 Main St 🏠, Portland, OR",
+			expected: "123 Main St , Portland, OR",
+		},
+		{
+			name:     "Emoji with variation selector and skin tone",
+			input:    "❤️ 45 Oak Ave 👍🏽",
+			expected: "45 Oak Ave",
+		},
+		{
+			name:     "Zero-width characters removed",
+			input:    "123​Main‍St",
+			expected: "123 Main St",
+		},
+		{
+			name:     "Accented letters kept",
+			input:    "123 Rue Saint-Honoré, Montréal, QC",
+			expected: "123 Rue Saint-Honoré, Montréal, QC",
+		},
+		{
+			name:     "Address symbols kept",
+			input:    "123 Main St #4 & 5th Ave / Elm",
+			expected: "123 Main St #4 & 5th Ave / Elm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripSymbols(tt.input); got != tt.expected {
+				t.Errorf("StripSymbols() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	p := NewParserWithOptions(ParseOptions{StripSymbols: true})
+	result, err := p.ParseLocation("123 Main St 🏠, Portland, OR 97201")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Address == nil || result.Address.Type != "st" || result.Address.City != "Portland" {
+		t.Errorf("ParseLocation() with StripSymbols = %+v", result.Address)
+	}
+	if _, err := p.ParseLocation("🏠🏠"); err == nil {
+		t.Error("ParseLocation() of emoji only: want an error")
+	}
+}
+
 // TestDenialOfService tests DoS attack resistance
 func TestDenialOfService(t *testing.T) {
 	p := NewParser()
@@ -137,6 +304,43 @@ func TestDenialOfService(t *testing.T) {
 	}
 }
 
+// TestStructureLimits ensures inputs with too many segments or tokens are
+// rejected up front
+func TestStructureLimits(t *testing.T) {
+	p := NewParserWithOptions(ParseOptions{MaxSegments: MaxSegments, MaxTokens: MaxTokens})
+
+	start := time.Now()
+	_, err := p.ParseLocation(strings.Repeat(",", 10000))
+	if !errors.Is(err, ErrTooManySegments) {
+		t.Errorf("10,000 commas: got error %v, want ErrTooManySegments", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("10,000 commas took %v to reject", elapsed)
+	}
+
+	_, err = p.ParseLocation(strings.Repeat("a ", 1000))
+	if !errors.Is(err, ErrTooManyTokens) {
+		t.Errorf("1,000 tokens: got error %v, want ErrTooManyTokens", err)
+	}
+
+	// Limits are configurable
+	strict := NewParserWithOptions(ParseOptions{MaxSegments: 2})
+	if _, err := strict.ParseLocation("123 Main St, Springfield, IL"); !errors.Is(err, ErrTooManySegments) {
+		t.Errorf("3 segments with a limit of 2: got error %v, want ErrTooManySegments", err)
+	}
+	if _, err := p.ParseLocation("123 Main St, Springfield, IL"); err != nil {
+		t.Errorf("3 segments with the suggested limit: unexpected error %v", err)
+	}
+
+	// Without limits only the input length is checked
+	if err := ValidateInput(strings.Repeat(",", 1000)); err != nil {
+		t.Errorf("1,000 commas with no limit: unexpected error %v", err)
+	}
+	if err := ValidateInput(strings.Repeat("a ", 1000)); err != nil {
+		t.Errorf("1,000 tokens with no limit: unexpected error %v", err)
+	}
+}
+
 // TestInjectionAttempts tests resistance to various injection attempts
 func TestInjectionAttempts(t *testing.T) {
 	p := NewParser()
@@ -260,6 +464,9 @@ func TestBoundaryConditions(t *testing.T) {
 		{"All lowercase", "123 main street new york ny 10001"},
 		{"Mixed case", "123 MaIn StReEt NeW yOrK nY 10001"},
 		{"Unicode characters", "123 Café St São Paulo"},
+		{"Emoji", "123 Main St 🏠 Sebastopol CA"},
+		{"Only emoji", "🏠👍🏽"},
+		{"Emoji between every rune", "1🏠2🏠3 M🏠a🏠i🏠n St"},
 		{"Numbers everywhere", "123 456 789 0"},
 		{"Many spaces", "123     Main     St"},
 		{"Comma at start", ",123 Main St"},
@@ -279,6 +486,55 @@ func TestBoundaryConditions(t *testing.T) {
 	}
 }
 
+func TestParseLocationEmoji(t *testing.T) {
+	input := "123 Main St 🏠 Sebastopol CA"
+	expected := ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Sebastopol", State: "CA"}
+
+	result, err := NewParser().ParseLocation(input)
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Address == nil || *result.Address != expected {
+		t.Errorf("got %+v, want %+v", result.Address, expected)
+	}
+
+	p := NewParserWithOptions(ParseOptions{RejectEmoji: true})
+	if _, err := p.ParseLocation(input); !errors.Is(err, ErrContainsEmoji) {
+		t.Errorf("RejectEmoji: got error %v, want ErrContainsEmoji", err)
+	}
+	if _, err := p.ParseLocation("123 Main St, Sebastopol, CA"); err != nil {
+		t.Errorf("RejectEmoji without emoji: got error %v", err)
+	}
+}
+
+// TestBoundaryFoldDiacritics checks the unicode boundary input with
+// diacritic folding enabled
+func TestBoundaryFoldDiacritics(t *testing.T) {
+	p := NewParserWithOptions(ParseOptions{FoldDiacritics: true})
+
+	result, err := p.ParseLocation("123 Café St São Paulo")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Address == nil || result.Address.Street != "Cafe St Sao Paulo" {
+		t.Fatalf("Street: got %+v, want %q", result.Address, "Cafe St Sao Paulo")
+	}
+	if result.Raw == nil || result.Raw.Street != "Café St São Paulo" {
+		t.Errorf("Raw street: got %+v, want %q", result.Raw, "Café St São Paulo")
+	}
+
+	result, err = p.ParseLocation("123 Main St, Cañon City, CO")
+	if err != nil {
+		t.Fatalf("ParseLocation() failed: %v", err)
+	}
+	if result.Address.City != "Canon City" {
+		t.Errorf("City: got %q, want %q", result.Address.City, "Canon City")
+	}
+	if result.Raw == nil || result.Raw.City != "Cañon City" {
+		t.Errorf("Raw city: got %+v, want %q", result.Raw, "Cañon City")
+	}
+}
+
 // TestConcurrentAccess tests thread safety
 func TestConcurrentAccess(t *testing.T) {
 	p := NewParser()
@@ -364,3 +620,69 @@ func TestRegexComplexity(t *testing.T) {
 		})
 	}
 }
+
+// TestNormalize tests cleaning input without parsing it
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "Whitespace collapsed",
+			input:    "  123\t Main   St\n\nSpringfield ",
+			expected: "123 MAIN ST SPRINGFIELD",
+		},
+		{
+			name:     "Control characters stripped",
+			input:    "123 Main\x07St\x1b, Springfield",
+			expected: "123 MAIN ST, SPRINGFIELD",
+		},
+		{
+			name:     "Comma spacing",
+			input:    "123 Main St ,Springfield,,IL 62704",
+			expected: "123 MAIN ST, SPRINGFIELD, IL 62704",
+		},
+		{
+			name:    "Null byte rejected",
+			input:   "123 Main\x00St",
+			wantErr: ErrInvalidCharacters,
+		},
+		{
+			name:    "Only separators",
+			input:   " , \x07 ",
+			wantErr: ErrInputEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Normalize() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("Normalize() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParserNormalize tests that Parser.Normalize applies the parser's
+// options
+func TestParserNormalize(t *testing.T) {
+	strict := NewParserWithOptions(ParseOptions{MaxSegments: 2, RejectEmoji: true})
+	if _, err := strict.Normalize("123 Main St, Springfield, IL"); !errors.Is(err, ErrTooManySegments) {
+		t.Errorf("3 segments with a limit of 2: got error %v, want ErrTooManySegments", err)
+	}
+	if _, err := strict.Normalize("123 Main St 🏠"); !errors.Is(err, ErrContainsEmoji) {
+		t.Errorf("emoji: got error %v, want ErrContainsEmoji", err)
+	}
+
+	p := NewParser()
+	p.SetPreprocessor(func(s string) string { return strings.ReplaceAll(s, " - ", ", ") })
+	if got, err := p.Normalize("123 Main St - Springfield"); err != nil || got != "123 MAIN ST, SPRINGFIELD" {
+		t.Errorf("preprocessor: got %q, %v, want %q", got, err, "123 MAIN ST, SPRINGFIELD")
+	}
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownState     = errors.New("state is not a known US state or territory")
+	ErrInvalidZIP       = errors.New("ZIP code must start with 5 digits")
+	ErrStateZIPMismatch = errors.New("ZIP code is not used in the state")
+)
+
+// zipRange is a half-open range [from, to) of 3-digit ZIP prefixes
+type zipRange struct {
+	from, to int
+}
+
+// stateZIPRanges maps each state code to the 3-digit ZIP prefixes assigned
+// to it. Some prefixes are shared across a border (055 is Massachusetts
+// inside Vermont's range, 063 covers Fishers Island, NY).
+var stateZIPRanges = map[string][]zipRange{
+	"AL": {{350, 370}},
+	"AK": {{995, 1000}},
+	"AZ": {{850, 866}},
+	"AR": {{716, 730}},
+	"CA": {{900, 962}},
+	"CO": {{800, 817}},
+	"CT": {{60, 70}},
+	"DE": {{197, 200}},
+	"DC": {{200, 206}, {569, 570}},
+	"FL": {{320, 350}},
+	"GA": {{300, 320}, {398, 400}},
+	"HI": {{967, 969}},
+	"ID": {{832, 839}},
+	"IL": {{600, 630}},
+	"IN": {{460, 480}},
+	"IA": {{500, 529}},
+	"KS": {{660, 680}},
+	"KY": {{400, 428}},
+	"LA": {{700, 715}},
+	"ME": {{39, 50}},
+	"MD": {{206, 220}},
+	"MA": {{10, 28}, {55, 56}},
+	"MI": {{480, 500}},
+	"MN": {{550, 568}},
+	"MS": {{386, 398}},
+	"MO": {{630, 659}},
+	"MT": {{590, 600}},
+	"NE": {{680, 694}},
+	"NV": {{889, 899}},
+	"NH": {{30, 39}},
+	"NJ": {{70, 90}},
+	"NM": {{870, 885}},
+	"NY": {{5, 6}, {63, 64}, {100, 150}},
+	"NC": {{270, 290}},
+	"ND": {{580, 589}},
+	"OH": {{430, 460}},
+	"OK": {{730, 750}},
+	"OR": {{970, 980}},
+	"PA": {{150, 197}},
+	"RI": {{28, 30}},
+	"SC": {{290, 300}},
+	"SD": {{570, 578}},
+	"TN": {{370, 386}},
+	"TX": {{750, 800}, {885, 886}},
+	"UT": {{840, 848}},
+	"VT": {{50, 55}, {56, 60}},
+	"VA": {{201, 202}, {220, 247}},
+	"WA": {{980, 995}},
+	"WV": {{247, 269}},
+	"WI": {{530, 550}},
+	"WY": {{820, 832}},
+	"AS": {{967, 968}},
+	"FM": {{969, 970}},
+	"GU": {{969, 970}},
+	"MH": {{969, 970}},
+	"MP": {{969, 970}},
+	"PR": {{6, 8}, {9, 10}},
+	"PW": {{969, 970}},
+	"VI": {{8, 9}},
+}
+
+// ValidateStateZIP checks that the ZIP code is one the USPS assigns to the
+// state ("CA" with "10001" fails), using the ZIP's 3-digit prefix. The state
+// may be a code or a name; a ZIP+4 is checked by its first five digits. It
+// is a data-quality check to run after parsing, which never rejects a
+// mismatch itself. Errors wrap ErrUnknownState, ErrInvalidZIP or
+// ErrStateZIPMismatch.
+func ValidateStateZIP(state, zip string) error {
+	code := NormalizeState(state)
+	ranges, ok := stateZIPRanges[code]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownState, state)
+	}
+
+	zip = strings.TrimSpace(zip)
+	if len(zip) < 5 || !isDigits(zip[:5]) {
+		return fmt.Errorf("%w: %q", ErrInvalidZIP, zip)
+	}
+	prefix, _ := strconv.Atoi(zip[:3])
+	for _, r := range ranges {
+		if prefix >= r.from && prefix < r.to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not in %s", ErrStateZIPMismatch, zip[:5], code)
+}
+
+// stateForZIP returns the state whose ZIP prefixes include zip, or "" when
+// none or several do (969 is shared by the Pacific territories)
+func stateForZIP(zip string) string {
+	if len(zip) < 5 || !isDigits(zip[:5]) {
+		return ""
+	}
+	prefix, _ := strconv.Atoi(zip[:3])
+	var found string
+	for code, ranges := range stateZIPRanges {
+		for _, r := range ranges {
+			if prefix >= r.from && prefix < r.to {
+				if found != "" {
+					return ""
+				}
+				found = code
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateStateZIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   string
+		zip     string
+		wantErr error
+	}{
+		{"Matching pair", "CA", "95472", nil},
+		{"State name", "New York", "10001", nil},
+		{"ZIP+4", "IL", "62701-1234", nil},
+		{"Leading zero", "MA", "02134", nil},
+		{"Prefix shared across a border", "MA", "05501", nil},
+		{"Territory", "PR", "00901", nil},
+		{"Half-open upper bound", "CA", "96201", ErrStateZIPMismatch},
+		{"Mismatched pair", "CA", "10001", ErrStateZIPMismatch},
+		{"Neighbouring state", "VT", "05501", ErrStateZIPMismatch},
+		{"Unknown state", "ZZ", "10001", ErrUnknownState},
+		{"Province", "QC", "10001", ErrUnknownState},
+		{"Short ZIP", "CA", "9547", ErrInvalidZIP},
+		{"Letters in ZIP", "CA", "9547A", ErrInvalidZIP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStateZIP(tt.state, tt.zip)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package parser
+
+import "math"
+
+// completeFields are the street address components a deliverable address
+// needs, in the order used to break ties for Stats.MostCommonMissing
+var completeFields = []string{"number", "street", "city", "state", "zip"}
+
+// Stats summarizes the results of parsing a batch of addresses
+type Stats struct {
+	Total             int            `json:"total"`
+	TypeCounts        map[string]int `json:"type_counts"`         // Results per ParseResult.Type; nil results count as "none"
+	AverageConfidence float64        `json:"average_confidence"`  // Mean Confidence over all results
+	WithZIPPercent    float64        `json:"with_zip_percent"`    // Share of results with a ZIP, 0-100
+	CompletePercent   float64        `json:"complete_percent"`    // Share of street addresses with number, street, city, state and ZIP, 0-100
+	MostCommonMissing string         `json:"most_common_missing"` // JSON name of the component street addresses lack most often
+}
+
+// BatchStats computes aggregate statistics over batch parse output, e.g.
+// from ParseBatch
+func BatchStats(results []*ParseResult) Stats {
+	stats := Stats{
+		Total:      len(results),
+		TypeCounts: make(map[string]int),
+	}
+	if len(results) == 0 {
+		return stats
+	}
+
+	var confidence float64
+	var withZIP, addresses, complete int
+	missing := make(map[string]int)
+
+	for _, r := range results {
+		if r == nil {
+			stats.TypeCounts["none"]++
+			continue
+		}
+		stats.TypeCounts[r.Type]++
+		confidence += r.Confidence
+
+		if resultZIP(r) != "" {
+			withZIP++
+		}
+
+		if r.Type != "address" || r.Address == nil {
+			continue
+		}
+		addresses++
+		values := addressValues(r.Address)
+		full := true
+		for _, f := range completeFields {
+			if values[f] == "" {
+				missing[f]++
+				full = false
+			}
+		}
+		if full {
+			complete++
+		}
+	}
+
+	stats.AverageConfidence = round2(confidence / float64(len(results)))
+	stats.WithZIPPercent = percent(withZIP, len(results))
+	stats.CompletePercent = percent(complete, addresses)
+
+	best := 0
+	for _, f := range completeFields {
+		if missing[f] > best {
+			best = missing[f]
+			stats.MostCommonMissing = f
+		}
+	}
+	return stats
+}
+
+// percent returns n as a percentage of total, rounded to 2 decimals
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round2(float64(n) * 100 / float64(total))
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBatchStats(t *testing.T) {
+	results := []*ParseResult{
+		{
+			Type:       "address",
+			Address:    &ParsedAddress{Number: "1005", Street: "Gravenstein", Type: "hwy", City: "Sebastopol", State: "CA", ZIP: "95472"},
+			Confidence: 1,
+		},
+		{
+			Type:       "address",
+			Address:    &ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL"},
+			Confidence: 0.85,
+		},
+		{
+			Type:       "address",
+			Address:    &ParsedAddress{Street: "Main", Type: "st"},
+			Confidence: 0.4,
+		},
+		{
+			Type:       "po_box",
+			Address:    &ParsedAddress{SecUnitType: "PO Box", SecUnitNum: "99", ZIP: "78701"},
+			Confidence: 0.75,
+		},
+		{Type: "none"},
+		nil,
+	}
+
+	stats := BatchStats(results)
+
+	if stats.Total != 6 {
+		t.Errorf("Total: got %d, want 6", stats.Total)
+	}
+	wantTypes := map[string]int{"address": 3, "po_box": 1, "none": 2}
+	for typ, n := range wantTypes {
+		if stats.TypeCounts[typ] != n {
+			t.Errorf("TypeCounts[%q]: got %d, want %d", typ, stats.TypeCounts[typ], n)
+		}
+	}
+	if stats.AverageConfidence != 0.5 {
+		t.Errorf("AverageConfidence: got %v, want 0.5", stats.AverageConfidence)
+	}
+	if stats.WithZIPPercent != 33.33 {
+		t.Errorf("WithZIPPercent: got %v, want 33.33", stats.WithZIPPercent)
+	}
+	if stats.CompletePercent != 33.33 {
+		t.Errorf("CompletePercent: got %v, want 33.33", stats.CompletePercent)
+	}
+	if stats.MostCommonMissing != "zip" {
+		t.Errorf("MostCommonMissing: got %q, want zip", stats.MostCommonMissing)
+	}
+}
+
+func TestBatchStatsEmpty(t *testing.T) {
+	stats := BatchStats(nil)
+	if stats.Total != 0 || stats.AverageConfidence != 0 || stats.MostCommonMissing != "" {
+		t.Errorf("got %+v, want zero stats", stats)
+	}
+}
+
+func TestBatchStatsFromParseBatch(t *testing.T) {
+	p := NewParser()
+	results, err := p.ParseBatch(context.Background(), []string{
+		"1005 N Gravenstein Hwy, Sebastopol, CA 95472",
+		"PO Box 1234, Denver, CO 80201",
+	})
+	if err != nil {
+		t.Fatalf("ParseBatch() failed: %v", err)
+	}
+
+	stats := BatchStats(results)
+	if stats.WithZIPPercent != 100 {
+		t.Errorf("WithZIPPercent: got %v, want 100", stats.WithZIPPercent)
+	}
+	if stats.CompletePercent != 100 {
+		t.Errorf("CompletePercent: got %v, want 100", stats.CompletePercent)
+	}
+}
@@ -0,0 +1,216 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// streetTypeNames maps each standard street type abbreviation to its
+// spelled-out name, the longest of its variations ("st" -> "street")
+var streetTypeNames = func() map[string]string {
+	names := make(map[string]string)
+	for variant, abbr := range StreetType {
+		name, ok := names[abbr]
+		if !ok || len(variant) > len(name) || len(variant) == len(name) && variant < name {
+			names[abbr] = variant
+		}
+	}
+	return names
+}()
+
+// Suggest returns corrected versions of the address for a "did you mean"
+// prompt when it has a common mistake: a misspelled street type ("Main
+// Stret"), a misspelled state name ("Illinoi"), or a missing state that the
+// ZIP identifies. Fixes build on each other, so every suggestion has all of
+// them; a word close to several names gives one suggestion per name. It
+// returns nil when nothing needs correcting or the input fails validation.
+func (p *Parser) Suggest(address string) []string {
+	sanitized, err := p.sanitize(address)
+	if err != nil {
+		return nil
+	}
+
+	candidates := []string{sanitized}
+	for _, fix := range []func(string) []string{p.suggestStreetType, p.suggestStateName, p.suggestState} {
+		var next []string
+		for _, c := range candidates {
+			if fixed := fix(c); len(fixed) > 0 {
+				next = append(next, fixed...)
+			} else {
+				next = append(next, c)
+			}
+		}
+		candidates = next
+	}
+
+	var suggestions []string
+	seen := map[string]bool{sanitized: true}
+	for _, c := range candidates {
+		if !seen[c] {
+			seen[c] = true
+			suggestions = append(suggestions, c)
+		}
+	}
+	return suggestions
+}
+
+// suggestStreetType corrects the first word after the house number that is
+// one edit away from a spelled-out street type ("Stret" -> "Street"). It
+// stops at a word that already is a street type or ends the first segment.
+func (p *Parser) suggestStreetType(address string) []string {
+	words := strings.Fields(address)
+	for i := 1; i < len(words); i++ {
+		word := strings.TrimRight(words[i], ",.")
+		if p.isStreetType(word) {
+			return nil
+		}
+		if !p.patterns.number.MatchString(words[i-1]) && NormalizeDirectional(word) == "" && NormalizeState(word) == "" {
+			var out []string
+			for _, name := range closeStreetTypes(word) {
+				out = append(out, replaceWords(words, i, i+1, matchCase(name, word)))
+			}
+			if len(out) > 0 {
+				return out
+			}
+		}
+		if strings.HasSuffix(words[i], ",") {
+			return nil
+		}
+	}
+	return nil
+}
+
+// suggestStateName corrects a state name one edit away from a real one
+// ("Illinoi", "New Hampshir") as the last one or two words before the ZIP
+func (p *Parser) suggestStateName(address string) []string {
+	if p.ParseAddress(address).State != "" {
+		return nil
+	}
+	words := strings.Fields(address)
+	end := len(words)
+	if end > 0 && p.patterns.zip.MatchString(words[end-1]) {
+		end--
+	}
+	for n := 2; n >= 1; n-- {
+		if end-n < 1 {
+			continue
+		}
+		var parts []string
+		for _, w := range words[end-n : end] {
+			parts = append(parts, strings.TrimRight(w, ",."))
+		}
+		written := strings.Join(parts, " ")
+		var out []string
+		for _, name := range closeWords(written, stateNames()) {
+			out = append(out, replaceWords(words, end-n, end, matchCase(name, written)))
+		}
+		if len(out) > 0 {
+			return out
+		}
+	}
+	return nil
+}
+
+// suggestState adds a missing state in front of the ZIP when the ZIP
+// belongs to exactly one state
+func (p *Parser) suggestState(address string) []string {
+	parsed := p.ParseAddress(address)
+	if parsed.State != "" || parsed.ZIP == "" {
+		return nil
+	}
+	state := stateForZIP(parsed.ZIP)
+	locs := p.patterns.zip.FindAllStringIndex(address, -1)
+	if state == "" || len(locs) == 0 {
+		return nil
+	}
+	at := locs[len(locs)-1][0]
+	return []string{address[:at] + state + " " + address[at:]}
+}
+
+// closeStreetTypes returns the spelled-out street types one edit away from
+// word. Only variations of five letters or more are compared, as shorter
+// ones are too close to ordinary words.
+func closeStreetTypes(word string) []string {
+	var variants []string
+	for variant := range StreetType {
+		if len(variant) >= 5 {
+			variants = append(variants, variant)
+		}
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, variant := range closeWords(word, variants) {
+		if name := streetTypeNames[StreetType[variant]]; !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// stateNames lists the spelled-out state names
+func stateNames() []string {
+	names := make([]string, 0, len(StateCode))
+	for name := range StateCode {
+		names = append(names, name)
+	}
+	return names
+}
+
+// closeWords returns the entries of dict exactly one edit away from word,
+// ignoring case, sorted. Words shorter than four letters never match.
+func closeWords(word string, dict []string) []string {
+	word = strings.ToLower(word)
+	if len(word) < 4 {
+		return nil
+	}
+	var out []string
+	for _, entry := range dict {
+		if editDistance(word, entry) == 1 {
+			out = append(out, entry)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// editDistance is the Levenshtein distance between a and b in runes
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(min(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// matchCase writes name in capitals when written was, and in title case
+// otherwise
+func matchCase(name, written string) string {
+	if written == strings.ToUpper(written) {
+		return strings.ToUpper(name)
+	}
+	return titleCase(name)
+}
+
+// replaceWords joins words with words[from:to] replaced by text, keeping
+// the punctuation that ended the last replaced word
+func replaceWords(words []string, from, to int, text string) string {
+	last := words[to-1]
+	text += last[len(strings.TrimRight(last, ",.")):]
+	out := append(append(append([]string{}, words[:from]...), text), words[to:]...)
+	return strings.Join(out, " ")
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	p := NewParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "Misspelled street type",
+			input:    "123 Main Stret, Springfield, IL 62704",
+			expected: []string{"123 Main Street, Springfield, IL 62704"},
+		},
+		{
+			name:     "Misspelled street type on a single line",
+			input:    "123 MAIN STRET SPRINGFIELD IL 62704",
+			expected: []string{"123 MAIN STREET SPRINGFIELD IL 62704"},
+		},
+		{
+			name:     "Misspelled state name",
+			input:    "123 Main St, Springfield, Illinoi 62704",
+			expected: []string{"123 Main St, Springfield, Illinois 62704"},
+		},
+		{
+			name:     "Misspelled two-word state name",
+			input:    "10 Elm St, Concord, New Hampshir 03301",
+			expected: []string{"10 Elm St, Concord, New Hampshire 03301"},
+		},
+		{
+			name:     "Missing state from the ZIP",
+			input:    "123 Main St, Springfield 62704",
+			expected: []string{"123 Main St, Springfield IL 62704"},
+		},
+		{
+			name:     "Fixes combine",
+			input:    "123 Main Stret, Springfield 62704",
+			expected: []string{"123 Main Street, Springfield IL 62704"},
+		},
+		{
+			name:  "Nothing to correct",
+			input: "123 Main St, Springfield, IL 62704",
+		},
+		{
+			name:  "Street name close to a street type is kept",
+			input: "45 Greek Way, Athens GA",
+		},
+		{
+			name:  "Invalid input",
+			input: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Suggest(tt.input); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"stret", "street", 1},
+		{"street", "street", 0},
+		{"", "ave", 3},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q): got %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
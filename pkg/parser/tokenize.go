@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TokenKind classifies a Token
+type TokenKind string
+
+// Token kinds returned by Tokenize
+const (
+	TokenNumber      TokenKind = "number"      // Starts with a digit ("123", "12B")
+	TokenDirectional TokenKind = "directional" // "N", "North", "NE"
+	TokenType        TokenKind = "type"        // Street type ("St", "Avenue")
+	TokenState       TokenKind = "state"       // Two-letter state code
+	TokenZIP         TokenKind = "zip"         // ZIP or ZIP+4 after the first token
+	TokenWord        TokenKind = "word"        // Anything else
+)
+
+// Token is one whitespace-separated piece of an address
+type Token struct {
+	Text string    `json:"text"`
+	Kind TokenKind `json:"kind"`
+}
+
+var tokenZIP = regexp.MustCompile(`^\d{5}(?:-\d{4})?$`)
+
+// Tokenize splits an address into tokens and classifies each one from the
+// token and its neighbours alone, without parsing the address. Commas and
+// trailing periods are dropped from the text.
+//
+// The rules are applied in order: a token starting with a digit is a
+// number, or a ZIP when it is not the first token; a state code is a state
+// when a ZIP follows it or it ends the input after a comma (so "Oak Ct"
+// keeps "Ct" as a type); then directionals, built-in street types and
+// words. The same input always gives the same tokens.
+func Tokenize(address string) []Token {
+	type piece struct {
+		text    string
+		segment int
+	}
+	var pieces []piece
+	for i, segment := range strings.Split(address, ",") {
+		for _, field := range strings.Fields(segment) {
+			if text := strings.TrimRight(field, ".;"); text != "" {
+				pieces = append(pieces, piece{text, i})
+			}
+		}
+	}
+
+	tokens := make([]Token, len(pieces))
+	for i, pc := range pieces {
+		tokens[i].Text = pc.text
+		switch {
+		case pc.text[0] >= '0' && pc.text[0] <= '9':
+			if i > 0 && tokenZIP.MatchString(pc.text) {
+				tokens[i].Kind = TokenZIP
+			} else {
+				tokens[i].Kind = TokenNumber
+			}
+		case len(pc.text) == 2 && NormalizeState(pc.text) != "" &&
+			((i+1 < len(pieces) && tokenZIP.MatchString(pieces[i+1].text)) ||
+				(i+1 == len(pieces) && pc.segment > 0)):
+			tokens[i].Kind = TokenState
+		case NormalizeDirectional(pc.text) != "":
+			tokens[i].Kind = TokenDirectional
+		case isStreetType(pc.text):
+			tokens[i].Kind = TokenType
+		default:
+			tokens[i].Kind = TokenWord
+		}
+	}
+	return tokens
+}
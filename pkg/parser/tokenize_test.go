@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Token
+	}{
+		{
+			name:  "Full address",
+			input: "123 N Main St., Springfield, IL 62704-1234",
+			expected: []Token{
+				{"123", TokenNumber},
+				{"N", TokenDirectional},
+				{"Main", TokenWord},
+				{"St", TokenType},
+				{"Springfield", TokenWord},
+				{"IL", TokenState},
+				{"62704-1234", TokenZIP},
+			},
+		},
+		{
+			name:  "Five-digit house number",
+			input: "12345 Oak Ave NE Seattle WA 98101",
+			expected: []Token{
+				{"12345", TokenNumber},
+				{"Oak", TokenWord},
+				{"Ave", TokenType},
+				{"NE", TokenDirectional},
+				{"Seattle", TokenWord},
+				{"WA", TokenState},
+				{"98101", TokenZIP},
+			},
+		},
+		{
+			name:  "Court is a type, Connecticut a state",
+			input: "100 Oak Ct, Hartford, CT",
+			expected: []Token{
+				{"100", TokenNumber},
+				{"Oak", TokenWord},
+				{"Ct", TokenType},
+				{"Hartford", TokenWord},
+				{"CT", TokenState},
+			},
+		},
+		{
+			name:     "Empty",
+			input:    " , ",
+			expected: []Token{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tokenize(tt.input); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -2,76 +2,206 @@ package parser
 
 import (
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // ParsedAddress represents a fully parsed street address
 type ParsedAddress struct {
-	Number      string `json:"number,omitempty"`
-	Prefix      string `json:"prefix,omitempty"`
-	Street      string `json:"street,omitempty"`
-	Type        string `json:"type,omitempty"`
-	Suffix      string `json:"suffix,omitempty"`
-	SecUnitType string `json:"sec_unit_type,omitempty"`
-	SecUnitNum  string `json:"sec_unit_num,omitempty"`
-	City        string `json:"city,omitempty"`
-	State       string `json:"state,omitempty"`
-	ZIP         string `json:"zip,omitempty"`
-	Plus4       string `json:"plus4,omitempty"`
+	Attention    string `json:"attention,omitempty" xml:"attention,omitempty"` // From a leading "Attn:" segment
+	CareOf       string `json:"care_of,omitempty" xml:"care_of,omitempty"`     // Recipient from a leading "c/o" segment
+	BuildingName string `json:"building_name,omitempty" xml:"building_name,omitempty"`
+	Number       string `json:"number,omitempty" xml:"number,omitempty"`
+	NumberLow    string `json:"number_low,omitempty" xml:"number_low,omitempty"`   // Ends of a house number range ("100-110")
+	NumberHigh   string `json:"number_high,omitempty" xml:"number_high,omitempty"` // with Number holding the whole range
+	Prefix       string `json:"prefix,omitempty" xml:"prefix,omitempty"`
+	Street       string `json:"street,omitempty" xml:"street,omitempty"`
+	Type         string `json:"type,omitempty" xml:"type,omitempty"`
+	Suffix       string `json:"suffix,omitempty" xml:"suffix,omitempty"`
+	SecUnitType  string `json:"sec_unit_type,omitempty" xml:"sec_unit_type,omitempty"`
+	SecUnitNum   string `json:"sec_unit_num,omitempty" xml:"sec_unit_num,omitempty"`
+	SecUnitType2 string `json:"sec_unit_type2,omitempty" xml:"sec_unit_type2,omitempty"` // Second unit, as in "Apt 4, Bldg B"
+	SecUnitNum2  string `json:"sec_unit_num2,omitempty" xml:"sec_unit_num2,omitempty"`
+	City         string `json:"city,omitempty" xml:"city,omitempty"`
+	County       string `json:"county,omitempty" xml:"county,omitempty"` // From a "Sonoma County" or "County of Sonoma" segment
+	State        string `json:"state,omitempty" xml:"state,omitempty"`
+	ZIP          string `json:"zip,omitempty" xml:"zip,omitempty"`
+	Plus4        string `json:"plus4,omitempty" xml:"plus4,omitempty"`
+	Country      string `json:"country,omitempty" xml:"country,omitempty"`           // ISO 3166-1 alpha-2 code ("US")
+	Parcel       string `json:"parcel,omitempty" xml:"parcel,omitempty"`             // Assessor's parcel number ("Parcel 123-45-678")
+	RouteNumber  string `json:"route_number,omitempty" xml:"route_number,omitempty"` // Highway number when Street is a route ("I-80")
 }
 
+// GeneralDelivery is the SecUnitType of a po_box result for mail held at the
+// post office ("General Delivery, Juneau, AK 99801")
+const GeneralDelivery = "General Delivery"
+
 // ParsedIntersection represents a street intersection
 type ParsedIntersection struct {
-	Prefix1 string `json:"prefix1,omitempty"`
-	Street1 string `json:"street1,omitempty"`
-	Type1   string `json:"type1,omitempty"`
-	Suffix1 string `json:"suffix1,omitempty"`
-	Prefix2 string `json:"prefix2,omitempty"`
-	Street2 string `json:"street2,omitempty"`
-	Type2   string `json:"type2,omitempty"`
-	Suffix2 string `json:"suffix2,omitempty"`
-	City    string `json:"city,omitempty"`
-	State   string `json:"state,omitempty"`
-	ZIP     string `json:"zip,omitempty"`
+	Prefix1 string `json:"prefix1,omitempty" xml:"prefix1,omitempty"`
+	Street1 string `json:"street1,omitempty" xml:"street1,omitempty"`
+	Type1   string `json:"type1,omitempty" xml:"type1,omitempty"`
+	Suffix1 string `json:"suffix1,omitempty" xml:"suffix1,omitempty"`
+	Prefix2 string `json:"prefix2,omitempty" xml:"prefix2,omitempty"`
+	Street2 string `json:"street2,omitempty" xml:"street2,omitempty"`
+	Type2   string `json:"type2,omitempty" xml:"type2,omitempty"`
+	Suffix2 string `json:"suffix2,omitempty" xml:"suffix2,omitempty"`
+	City    string `json:"city,omitempty" xml:"city,omitempty"`
+	State   string `json:"state,omitempty" xml:"state,omitempty"`
+	ZIP     string `json:"zip,omitempty" xml:"zip,omitempty"`
+	Country string `json:"country,omitempty" xml:"country,omitempty"`
 }
 
 // ParseResult is a union type that can hold different parse results
 type ParseResult struct {
-	Type         string              `json:"type"` // "address", "intersection", "po_box", "none"
-	Address      *ParsedAddress      `json:"address,omitempty"`
-	Intersection *ParsedIntersection `json:"intersection,omitempty"`
+	Type         string              `json:"type" xml:"type"` // "address", "intersection", "po_box", "none"
+	Address      *ParsedAddress      `json:"address,omitempty" xml:"address,omitempty"`
+	Intersection *ParsedIntersection `json:"intersection,omitempty" xml:"intersection,omitempty"`
+	Confidence   float64             `json:"confidence,omitempty" xml:"confidence,omitempty"`     // 0-1, set by ParseLocation
+	RunnerUp     *ParseResult        `json:"runner_up,omitempty" xml:"runner_up,omitempty"`       // Next best interpretation, if any
+	Partial      bool                `json:"partial,omitempty" xml:"partial,omitempty"`           // Address has no street line, e.g. a bare ZIP
+	Warnings     []string            `json:"warnings,omitempty" xml:"warnings>warning,omitempty"` // Ambiguous tokens and close alternatives
+
+	// DetectedType is the type ParseLocation would have chosen ("address",
+	// "intersection", "po_box"), set by ParseAs for a specific parse type
+	// so a mismatch with the requested parser is visible
+	DetectedType string `json:"detected_type,omitempty" xml:"detected_type,omitempty"`
+
+	// Undeliverable says why the input has no mailing address, such as
+	// "vacant land"; empty when it looks deliverable
+	Undeliverable string `json:"undeliverable,omitempty" xml:"undeliverable,omitempty"`
+
+	// Phone and Email hold contact details removed from the input with
+	// ParseOptions.StripContacts
+	Phone string `json:"phone,omitempty" xml:"phone,omitempty"`
+	Email string `json:"email,omitempty" xml:"email,omitempty"`
+
+	// Notes holds delivery instructions removed from the input, such as
+	// "ring buzzer" or "gate code 1234", joined with "; "
+	Notes string `json:"notes,omitempty" xml:"notes,omitempty"`
+
+	// Raw holds Address fields as written before normalization
+	// ("Highway" for Type "hwy"); only fields that normalization changed
+	// are set, so an already canonical "NV" leaves Raw.State empty
+	Raw *ParsedAddress `json:"raw,omitempty" xml:"raw,omitempty"`
+
+	// Presence maps each field the parser attempted (by JSON name) to
+	// whether a value was found. Fields the parser never looks for are
+	// absent. Only set with ParseOptions.ReportPresence. Omitted from XML,
+	// which has no encoding for maps.
+	Presence map[string]bool `json:"presence,omitempty" xml:"-"`
 }
 
 // IsEmpty checks if all fields of ParsedAddress are empty
 func (p *ParsedAddress) IsEmpty() bool {
-	return p.Number == "" &&
+	return p.Attention == "" &&
+		p.CareOf == "" &&
+		p.BuildingName == "" &&
+		p.Number == "" &&
+		p.NumberLow == "" &&
+		p.NumberHigh == "" &&
 		p.Prefix == "" &&
 		p.Street == "" &&
 		p.Type == "" &&
 		p.Suffix == "" &&
 		p.SecUnitType == "" &&
 		p.SecUnitNum == "" &&
+		p.SecUnitType2 == "" &&
+		p.SecUnitNum2 == "" &&
 		p.City == "" &&
+		p.County == "" &&
 		p.State == "" &&
 		p.ZIP == "" &&
-		p.Plus4 == ""
+		p.Plus4 == "" &&
+		p.Country == "" &&
+		p.Parcel == "" &&
+		p.RouteNumber == ""
 }
 
 // Normalize applies title casing and trimming to address fields
 func (p *ParsedAddress) Normalize() {
+	p.Attention = strings.TrimSpace(p.Attention)
+	p.CareOf = strings.TrimSpace(p.CareOf)
+	p.BuildingName = strings.TrimSpace(p.BuildingName)
 	p.Number = strings.TrimSpace(p.Number)
+	p.NumberLow = strings.TrimSpace(p.NumberLow)
+	p.NumberHigh = strings.TrimSpace(p.NumberHigh)
 	p.Prefix = strings.TrimSpace(p.Prefix)
-	p.Street = titleCase(p.Street)
-	p.Type = strings.TrimSpace(p.Type)
+	p.Street = titleCase(dropPlacePeriods(trimPunctuation(p.Street)))
+	p.Type = trimPunctuation(p.Type)
 	p.Suffix = strings.TrimSpace(p.Suffix)
 	p.SecUnitType = strings.TrimSpace(p.SecUnitType)
 	p.SecUnitNum = strings.TrimSpace(p.SecUnitNum)
-	p.City = titleCase(p.City)
+	p.SecUnitType2 = strings.TrimSpace(p.SecUnitType2)
+	p.SecUnitNum2 = strings.TrimSpace(p.SecUnitNum2)
+	p.City = titleCase(dropPlacePeriods(trimPunctuation(p.City)))
+	p.County = titleCase(p.County)
 	p.State = strings.ToUpper(strings.TrimSpace(p.State))
 	p.ZIP = strings.TrimSpace(p.ZIP)
 	p.Plus4 = strings.TrimSpace(p.Plus4)
+	p.Country = strings.ToUpper(strings.TrimSpace(p.Country))
+	p.Parcel = strings.TrimSpace(p.Parcel)
+	p.RouteNumber = strings.TrimSpace(p.RouteNumber)
 }
 
-// titleCase converts a string to title case
+// Merge returns a copy of p with its empty fields filled from other, for
+// combining a parsed street line with a separately parsed locality. On a
+// conflict p wins: a field set in both keeps p's value, field by field, so
+// the caller should merge results that describe the same address. The
+// exceptions keep related fields together: NumberLow and NumberHigh come
+// with Number, and Plus4 is only taken along with other's ZIP (or when both
+// ZIPs agree) so a ZIP never gets another ZIP's extension. Neither
+// input is modified; a nil other gives a plain copy.
+func (p *ParsedAddress) Merge(other *ParsedAddress) *ParsedAddress {
+	merged := *p
+	if other == nil {
+		return &merged
+	}
+
+	fill := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	fill(&merged.Attention, other.Attention)
+	fill(&merged.CareOf, other.CareOf)
+	fill(&merged.BuildingName, other.BuildingName)
+	if p.Number == "" {
+		merged.Number, merged.NumberLow, merged.NumberHigh = other.Number, other.NumberLow, other.NumberHigh
+	}
+	fill(&merged.Prefix, other.Prefix)
+	fill(&merged.Street, other.Street)
+	fill(&merged.Type, other.Type)
+	fill(&merged.Suffix, other.Suffix)
+	fill(&merged.SecUnitType, other.SecUnitType)
+	fill(&merged.SecUnitNum, other.SecUnitNum)
+	fill(&merged.SecUnitType2, other.SecUnitType2)
+	fill(&merged.SecUnitNum2, other.SecUnitNum2)
+	fill(&merged.City, other.City)
+	fill(&merged.County, other.County)
+	fill(&merged.State, other.State)
+	if p.ZIP == "" || p.ZIP == other.ZIP {
+		fill(&merged.Plus4, other.Plus4)
+	}
+	fill(&merged.ZIP, other.ZIP)
+	fill(&merged.Country, other.Country)
+	fill(&merged.Parcel, other.Parcel)
+	fill(&merged.RouteNumber, other.RouteNumber)
+	return &merged
+}
+
+// trimPunctuation trims whitespace and stray separators (".", ",", ";",
+// ":") from both ends of s, as in "Springfield," or "Ave.". Hyphens,
+// apostrophes and anything inside the value are kept.
+func trimPunctuation(s string) string {
+	return strings.TrimFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(".,;:", r)
+	})
+}
+
+// titleCase converts a string to title case. Each hyphenated part is
+// capitalized ("Saint-Denis"), words in Acronyms are upper-cased, and
+// Connectors after the first word are lower-cased ("Ponce de Leon").
 func titleCase(s string) string {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -79,9 +209,29 @@ func titleCase(s string) string {
 	}
 	words := strings.Fields(s)
 	for i, word := range words {
-		if len(word) > 0 {
-			words[i] = strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
+		lower := strings.ToLower(word)
+		if i > 0 && (Connectors[lower] || connectorArticles[lower] && Connectors[strings.ToLower(words[i-1])]) {
+			words[i] = lower
+			continue
+		}
+		parts := strings.Split(word, "-")
+		for j, part := range parts {
+			if Acronyms[strings.ToLower(part)] {
+				parts[j] = strings.ToUpper(part)
+			} else {
+				parts[j] = capitalize(part)
+			}
 		}
+		words[i] = strings.Join(parts, "-")
 	}
 	return strings.Join(words, " ")
 }
+
+// capitalize upper-cases the first letter of word and lower-cases the rest
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	if size == 0 {
+		return word
+	}
+	return string(unicode.ToUpper(r)) + strings.ToLower(word[size:])
+}
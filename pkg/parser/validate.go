@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// FieldError reports a ParsedAddress field whose value does not have the
+// expected USPS format. Field is the JSON name, so forms can highlight the
+// input it came from.
+type FieldError struct {
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %q: %s", e.Field, e.Value, e.Message)
+}
+
+var (
+	validNumber = regexp.MustCompile(`^\d+(?:\s*[\-\x{2013}]\s*\d+)?[A-Za-z]?(?:\s+\d/\d)?$|^\d/\d$|^[NSEW]\d{1,3}[NSEW]\d{1,6}$`)
+	validZIP    = regexp.MustCompile(`^\d{5}$`)
+	validPlus4  = regexp.MustCompile(`^\d{4}$`)
+)
+
+// Validate checks each populated field against its expected format: Number
+// is digits with an optional range, letter or fraction ("12-14", "12B",
+// "12 1/2") or grid coordinates ("N6W23001"), State a known state or
+// province code, ZIP five digits, Plus4 four digits and Type a built-in
+// street type. Empty fields are not checked. It returns nil when every
+// field is valid.
+func (p *ParsedAddress) Validate() []FieldError {
+	var errs []FieldError
+	check := func(field, value string, ok bool, message string) {
+		if value != "" && !ok {
+			errs = append(errs, FieldError{Field: field, Value: value, Message: message})
+		}
+	}
+
+	check("number", p.Number, validNumber.MatchString(p.Number),
+		"must be digits, optionally with a range, letter or fraction")
+	check("state", p.State, NormalizeState(p.State) == p.State || NormalizeProvince(p.State) == p.State,
+		"must be a two-letter state abbreviation")
+	check("zip", p.ZIP, validZIP.MatchString(p.ZIP), "must be 5 digits")
+	check("plus4", p.Plus4, validPlus4.MatchString(p.Plus4), "must be 4 digits")
+	check("type", p.Type, isStreetType(p.Type) || NormalizeFrenchStreetType(p.Type) != "",
+		"must be a known street type")
+	return errs
+}
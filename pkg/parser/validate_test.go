@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsedAddressValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		address ParsedAddress
+		want    []string // Fields with errors
+	}{
+		{
+			name:    "Valid address",
+			address: ParsedAddress{Number: "123", Street: "Main", Type: "st", City: "Springfield", State: "IL", ZIP: "62704", Plus4: "1234"},
+		},
+		{
+			name:    "Range, letter and fraction numbers",
+			address: ParsedAddress{Number: "12 1/2", Street: "Main", Type: "st"},
+		},
+		{
+			name:    "Grid number",
+			address: ParsedAddress{Number: "N6W23001", Street: "Bluemound", Type: "rd"},
+		},
+		{
+			name:    "Bad state and 4-digit ZIP",
+			address: ParsedAddress{Number: "123", Street: "Main", Type: "st", State: "XX", ZIP: "6270"},
+			want:    []string{"state", "zip"},
+		},
+		{
+			name:    "Bad number, plus4 and type",
+			address: ParsedAddress{Number: "12a4", Street: "Main", Type: "strasse", ZIP: "62704", Plus4: "12"},
+			want:    []string{"number", "plus4", "type"},
+		},
+		{
+			name:    "Empty address",
+			address: ParsedAddress{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, err := range tt.address.Validate() {
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error fields: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	errs := (&ParsedAddress{State: "XX"}).Validate()
+	if len(errs) != 1 || errs[0].Value != "XX" || errs[0].Error() == "" {
+		t.Errorf("FieldError: got %+v", errs)
+	}
+}
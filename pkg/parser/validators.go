@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -13,6 +14,14 @@ const (
 
 	// MaxAddressLength is a reasonable max for a single address
 	MaxAddressLength = 500
+
+	// MaxSegments is a suggested limit on comma-separated segments, the
+	// servers' default
+	MaxSegments = 50
+
+	// MaxTokens is a suggested limit on whitespace-separated tokens, the
+	// servers' default
+	MaxTokens = 500
 )
 
 var (
@@ -20,10 +29,58 @@ var (
 	ErrInputEmpty        = errors.New("input is empty")
 	ErrInvalidCharacters = errors.New("input contains invalid characters")
 	ErrInvalidUTF8       = errors.New("input is not valid UTF-8")
+	ErrTooManySegments   = errors.New("input has too many comma-separated segments")
+	ErrTooManyTokens     = errors.New("input has too many tokens")
+	ErrContainsEmoji     = errors.New("input contains emoji")
 )
 
+// errorCodes maps the package's sentinel errors to the codes ErrorCode
+// returns. Codes are part of the API and must not change.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrInputTooLong, "INPUT_TOO_LONG"},
+	{ErrInputEmpty, "INPUT_EMPTY"},
+	{ErrInvalidCharacters, "INVALID_CHARACTERS"},
+	{ErrInvalidUTF8, "INVALID_UTF8"},
+	{ErrTooManySegments, "TOO_MANY_SEGMENTS"},
+	{ErrTooManyTokens, "TOO_MANY_TOKENS"},
+	{ErrContainsEmoji, "CONTAINS_EMOJI"},
+	{ErrInvalidPage, "INVALID_PAGE"},
+	{ErrUnknownState, "UNKNOWN_STATE"},
+	{ErrInvalidZIP, "INVALID_ZIP"},
+	{ErrStateZIPMismatch, "STATE_ZIP_MISMATCH"},
+}
+
+// ErrorCode returns a stable machine-readable code for err, such as
+// "INPUT_TOO_LONG" for an error wrapping ErrInputTooLong, so API clients
+// can branch on it. Errors wrapping none of the package's sentinel errors
+// give "PARSE_ERROR".
+func ErrorCode(err error) string {
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return "PARSE_ERROR"
+}
+
+// InputLimits bounds the structure of an input so pathological strings are
+// rejected before parsing. Zero fields mean no limit.
+type InputLimits struct {
+	MaxSegments int
+	MaxTokens   int
+}
+
 // ValidateInput performs security and sanity checks on input strings
 func ValidateInput(input string) error {
+	return ValidateInputLimits(input, InputLimits{})
+}
+
+// ValidateInputLimits is like ValidateInput with custom segment and token
+// limits
+func ValidateInputLimits(input string, limits InputLimits) error {
 	if input == "" {
 		return ErrInputEmpty
 	}
@@ -43,20 +100,65 @@ func ValidateInput(input string) error {
 		return ErrInvalidCharacters
 	}
 
+	// Limit the segments and tokens later stages iterate over
+	if limits.MaxSegments > 0 {
+		if n := strings.Count(input, ",") + 1; n > limits.MaxSegments {
+			return fmt.Errorf("%w: %d (max %d)", ErrTooManySegments, n, limits.MaxSegments)
+		}
+	}
+	if limits.MaxTokens > 0 {
+		if n := countTokens(input, limits.MaxTokens); n > limits.MaxTokens {
+			return fmt.Errorf("%w: more than %d", ErrTooManyTokens, limits.MaxTokens)
+		}
+	}
+
 	return nil
 }
 
-// SanitizeInput removes dangerous characters and normalizes whitespace
+// countTokens counts whitespace-separated tokens, stopping once the count
+// passes limit
+func countTokens(input string, limit int) int {
+	n := 0
+	inToken := false
+	for _, r := range input {
+		if unicode.IsSpace(r) {
+			inToken = false
+			continue
+		}
+		if !inToken {
+			inToken = true
+			if n++; n > limit {
+				break
+			}
+		}
+	}
+	return n
+}
+
+// SanitizeInput removes dangerous characters and emoji and normalizes
+// whitespace. Symbols that carry meaning in addresses ("#" for units, "&"
+// and "/" for intersections) are always kept.
 func SanitizeInput(input string) string {
 	// Remove null bytes
 	input = strings.ReplaceAll(input, "\x00", "")
 
+	// Emoji carry no address information; drop them before they are read
+	// as part of a city or street name
+	input = stripEmoji(input)
+
+	// Map full-width punctuation and middots to ASCII separators
+	input = normalizeSeparators(input)
+
 	// Normalize whitespace (tabs, newlines, etc. to single space)
 	input = strings.Join(strings.Fields(input), " ")
 
 	// Trim leading/trailing whitespace
 	input = strings.TrimSpace(input)
 
+	// Strip quotes left around the field by CSV extraction. Only a matched
+	// pair is removed, so an apostrophe ("O'Brien St") is kept.
+	input = stripQuotes(input)
+
 	// Limit length for safety
 	if len(input) > MaxAddressLength {
 		input = input[:MaxAddressLength]
@@ -65,9 +167,165 @@ func SanitizeInput(input string) string {
 	return input
 }
 
+// normalizeSeparators maps full-width ASCII forms ("，", "９") to ASCII and
+// turns middots and the ideographic comma into commas. A middot between a
+// letter and a lowercase letter is kept, as in the Catalan "Paral·lel".
+func normalizeSeparators(input string) string {
+	runes := []rune(input)
+	for i, r := range runes {
+		switch {
+		case r >= '\uFF01' && r <= '\uFF5E':
+			runes[i] = r - 0xFEE0
+		case r == '\u3001':
+			runes[i] = ','
+		case r == '\u00B7' || r == '\u30FB':
+			if i == 0 || i == len(runes)-1 || !unicode.IsLetter(runes[i-1]) || !unicode.IsLower(runes[i+1]) {
+				runes[i] = ','
+			}
+		}
+	}
+	return string(runes)
+}
+
+// stripQuotes removes matching single or double quotes wrapping the whole
+// input, repeatedly for nested quoting
+func stripQuotes(input string) string {
+	for len(input) >= 2 {
+		first, last := input[0], input[len(input)-1]
+		if first != last || (first != '"' && first != '\'') {
+			break
+		}
+		input = strings.TrimSpace(input[1 : len(input)-1])
+	}
+	return input
+}
+
+// isEmoji reports whether r is an emoji or pictograph: the emoji and
+// pictograph blocks (including regional indicators and skin tones),
+// miscellaneous symbols and dingbats
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r == 0x2B50 || r == 0x2B55 || r == 0x2B1B || r == 0x2B1C:
+		return true
+	}
+	return false
+}
+
+// isEmojiJoiner reports whether r only modifies or joins the emoji before
+// it: the variation selectors, zero-width joiner, combining keycap and tag
+// characters of flag sequences
+func isEmojiJoiner(r rune) bool {
+	return r == 0xFE0E || r == 0xFE0F || r == 0x200D || r == 0x20E3 ||
+		(r >= 0xE0020 && r <= 0xE007F)
+}
+
+// containsEmoji reports whether input holds any rune isEmoji accepts
+func containsEmoji(input string) bool {
+	return strings.IndexFunc(input, isEmoji) >= 0
+}
+
+// stripEmoji replaces each emoji sequence ("🏠", "👍🏽", "👨‍👩‍👧") with a space
+// so the words around it stay apart; whitespace is collapsed later
+func stripEmoji(input string) string {
+	if !containsEmoji(input) {
+		return input
+	}
+	var b strings.Builder
+	b.Grow(len(input))
+	inEmoji := false
+	for _, r := range input {
+		switch {
+		case isEmoji(r):
+			if !inEmoji {
+				b.WriteByte(' ')
+			}
+			inEmoji = true
+		case inEmoji && isEmojiJoiner(r):
+		default:
+			inEmoji = false
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// StripSymbols removes emoji, pictographs and non-printable runes while
+// keeping letters (including accented ones), digits, punctuation and
+// whitespace, then collapses the whitespace left behind
+func StripSymbols(input string) string {
+	input = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.Is(unicode.Variation_Selector, r):
+			return -1
+		case unicode.In(r, unicode.So, unicode.Sk, unicode.Co, unicode.Cs):
+			return -1
+		case unicode.IsControl(r) || unicode.Is(unicode.Cf, r):
+			return ' '
+		}
+		return r
+	}, input)
+	return strings.Join(strings.Fields(input), " ")
+}
+
+// Normalize validates and sanitizes input and returns it in a uniform form
+// without parsing it: control characters become spaces, whitespace is
+// collapsed, commas are followed by one space and letters are upper-cased,
+// as in USPS mailing style ("123  main st ,springfield" -> "123 MAIN ST,
+// SPRINGFIELD").
+func Normalize(input string) (string, error) {
+	sanitized, err := ValidateAndSanitize(input)
+	if err != nil {
+		return "", err
+	}
+	return normalizeSanitized(sanitized)
+}
+
+// Normalize is the package-level Normalize with the parser's options
+// applied: its input limits, emoji handling, preprocessor and optional
+// sanitization
+func (p *Parser) Normalize(input string) (string, error) {
+	sanitized, err := p.sanitize(input)
+	if err != nil {
+		return "", err
+	}
+	return normalizeSanitized(sanitized)
+}
+
+// normalizeSanitized puts sanitized input in the uniform form Normalize
+// returns
+func normalizeSanitized(sanitized string) (string, error) {
+	sanitized = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return ' '
+		}
+		return r
+	}, sanitized)
+
+	var segments []string
+	for _, segment := range strings.Split(sanitized, ",") {
+		if segment = strings.Join(strings.Fields(segment), " "); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) == 0 {
+		return "", ErrInputEmpty
+	}
+	return strings.ToUpper(strings.Join(segments, ", ")), nil
+}
+
 // ValidateAndSanitize combines validation and sanitization
 func ValidateAndSanitize(input string) (string, error) {
-	if err := ValidateInput(input); err != nil {
+	return ValidateAndSanitizeLimits(input, InputLimits{})
+}
+
+// ValidateAndSanitizeLimits is like ValidateAndSanitize with custom segment
+// and token limits
+func ValidateAndSanitizeLimits(input string, limits InputLimits) (string, error) {
+	if err := ValidateInputLimits(input, limits); err != nil {
 		return "", err
 	}
 	return SanitizeInput(input), nil
@@ -0,0 +1,21 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/xml"
+)
+
+// XML encodes the result as an indented XML document with a <result> root.
+// Field elements use the same snake_case names as the JSON encoding;
+// Presence is left out because encoding/xml cannot encode maps.
+func (r *ParseResult) XML() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.EncodeElement(r, xml.StartElement{Name: xml.Name{Local: "result"}}); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestParseResultXMLRoundTrip(t *testing.T) {
+	p := NewParser()
+
+	tests := []string{
+		"1005 N Gravenstein Highway, Suite 500, Sebastopol, CA 95472",
+		"Hollywood Blvd and Vine St, Los Angeles, CA",
+		"PO Box 1234, Austin, TX 78701",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			result, err := p.ParseLocation(input)
+			if err != nil {
+				t.Fatalf("ParseLocation() error = %v", err)
+			}
+
+			data, err := result.XML()
+			if err != nil {
+				t.Fatalf("XML() error = %v", err)
+			}
+			if !strings.HasPrefix(string(data), xml.Header+"<result>") {
+				t.Errorf("XML() = %q, want header and <result> root", data)
+			}
+
+			var got ParseResult
+			if err := xml.Unmarshal(data, &got); err != nil {
+				t.Fatalf("xml.Unmarshal() error = %v", err)
+			}
+			if got.Type != result.Type || got.Confidence != result.Confidence {
+				t.Errorf("got type %q confidence %v, want %q %v", got.Type, got.Confidence, result.Type, result.Confidence)
+			}
+			if (got.Address == nil) != (result.Address == nil) || got.Address != nil && *got.Address != *result.Address {
+				t.Errorf("got address %+v, want %+v", got.Address, result.Address)
+			}
+			if (got.Intersection == nil) != (result.Intersection == nil) || got.Intersection != nil && *got.Intersection != *result.Intersection {
+				t.Errorf("got intersection %+v, want %+v", got.Intersection, result.Intersection)
+			}
+			if strings.Join(got.Warnings, "|") != strings.Join(result.Warnings, "|") {
+				t.Errorf("got warnings %v, want %v", got.Warnings, result.Warnings)
+			}
+		})
+	}
+}
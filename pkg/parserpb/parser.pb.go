@@ -0,0 +1,1014 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v27.3.0
+// source: parser/v1/parser.proto
+
+package parserpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ParseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Type    string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (x *ParseRequest) Reset() {
+	*x = ParseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_v1_parser_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseRequest) ProtoMessage() {}
+
+func (x *ParseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_v1_parser_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseRequest.ProtoReflect.Descriptor instead.
+func (*ParseRequest) Descriptor() ([]byte, []int) {
+	return file_parser_v1_parser_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ParseRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ParseRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ParseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ParseResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *ParseResponse) Reset() {
+	*x = ParseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_v1_parser_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseResponse) ProtoMessage() {}
+
+func (x *ParseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_v1_parser_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseResponse.ProtoReflect.Descriptor instead.
+func (*ParseResponse) Descriptor() ([]byte, []int) {
+	return file_parser_v1_parser_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ParseResponse) GetResult() *ParseResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ParseBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Limit     int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset    int32    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *ParseBatchRequest) Reset() {
+	*x = ParseBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_v1_parser_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParseBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseBatchRequest) ProtoMessage() {}
+
+func (x *ParseBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_v1_parser_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseBatchRequest.ProtoReflect.Descriptor instead.
+func (*ParseBatchRequest) Descriptor() ([]byte, []int) {
+	return file_parser_v1_parser_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ParseBatchRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *ParseBatchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ParseBatchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ParseBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*ParseResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Total   int32          `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *ParseBatchResponse) Reset() {
+	*x = ParseBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_v1_parser_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParseBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseBatchResponse) ProtoMessage() {}
+
+func (x *ParseBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_v1_parser_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseBatchResponse.ProtoReflect.Descriptor instead.
+func (*ParseBatchResponse) Descriptor() ([]byte, []int) {
+	return file_parser_v1_parser_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ParseBatchResponse) GetResults() []*ParseResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ParseBatchResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ParsedAddress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildingName string `protobuf:"bytes,1,opt,name=building_name,json=buildingName,proto3" json:"building_name,omitempty"`
+	Number       string `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	Prefix       string `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Street       string `protobuf:"bytes,4,opt,name=street,proto3" json:"street,omitempty"`
+	Type         string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Suffix       string `protobuf:"bytes,6,opt,name=suffix,proto3" json:"suffix,omitempty"`
+	SecUnitType  string `protobuf:"bytes,7,opt,name=sec_unit_type,json=secUnitType,proto3" json:"sec_unit_type,omitempty"`
+	SecUnitNum   string `protobuf:"bytes,8,opt,name=sec_unit_num,json=secUnitNum,proto3" json:"sec_unit_num,omitempty"`
+	City         string `protobuf:"bytes,9,opt,name=city,proto3" json:"city,omitempty"`
+	State        string `protobuf:"bytes,10,opt,name=state,proto3" json:"state,omitempty"`
+	Zip          string `protobuf:"bytes,11,opt,name=zip,proto3" json:"zip,omitempty"`
+	Plus4        string `protobuf:"bytes,12,opt,name=plus4,proto3" json:"plus4,omitempty"`
+	SecUnitType2 string `protobuf:"bytes,13,opt,name=sec_unit_type2,json=secUnitType2,proto3" json:"sec_unit_type2,omitempty"`
+	SecUnitNum2  string `protobuf:"bytes,14,opt,name=sec_unit_num2,json=secUnitNum2,proto3" json:"sec_unit_num2,omitempty"`
+	CareOf       string `protobuf:"bytes,15,opt,name=care_of,json=careOf,proto3" json:"care_of,omitempty"`
+	Attention    string `protobuf:"bytes,16,opt,name=attention,proto3" json:"attention,omitempty"`
+	Parcel       string `protobuf:"bytes,17,opt,name=parcel,proto3" json:"parcel,omitempty"`
+	RouteNumber  string `protobuf:"bytes,18,opt,name=route_number,json=routeNumber,proto3" json:"route_number,omitempty"`
+	Country      string `protobuf:"bytes,19,opt,name=country,proto3" json:"country,omitempty"`
+	County       string `protobuf:"bytes,20,opt,name=county,proto3" json:"county,omitempty"`
+	NumberLow    string `protobuf:"bytes,21,opt,name=number_low,json=numberLow,proto3" json:"number_low,omitempty"`
+	NumberHigh   string `protobuf:"bytes,22,opt,name=number_high,json=numberHigh,proto3" json:"number_high,omitempty"`
+}
+
+func (x *ParsedAddress) Reset() {
+	*x = ParsedAddress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_v1_parser_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParsedAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParsedAddress) ProtoMessage() {}
+
+func (x *ParsedAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_v1_parser_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParsedAddress.ProtoReflect.Descriptor instead.
+func (*ParsedAddress) Descriptor() ([]byte, []int) {
+	return file_parser_v1_parser_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ParsedAddress) GetBuildingName() string {
+	if x != nil {
+		return x.BuildingName
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetSuffix() string {
+	if x != nil {
+		return x.Suffix
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetSecUnitType() string {
+	if x != nil {
+		return x.SecUnitType
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetSecUnitNum() string {
+	if x != nil {
+		return x.SecUnitNum
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetZip() string {
+	if x != nil {
+		return x.Zip
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetPlus4() string {
+	if x != nil {
+		return x.Plus4
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetSecUnitType2() string {
+	if x != nil {
+		return x.SecUnitType2
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetSecUnitNum2() string {
+	if x != nil {
+		return x.SecUnitNum2
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetCareOf() string {
+	if x != nil {
+		return x.CareOf
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetAttention() string {
+	if x != nil {
+		return x.Attention
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetParcel() string {
+	if x != nil {
+		return x.Parcel
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetRouteNumber() string {
+	if x != nil {
+		return x.RouteNumber
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetCounty() string {
+	if x != nil {
+		return x.County
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetNumberLow() string {
+	if x != nil {
+		return x.NumberLow
+	}
+	return ""
+}
+
+func (x *ParsedAddress) GetNumberHigh() string {
+	if x != nil {
+		return x.NumberHigh
+	}
+	return ""
+}
+
+type ParsedIntersection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix1 string `protobuf:"bytes,1,opt,name=prefix1,proto3" json:"prefix1,omitempty"`
+	Street1 string `protobuf:"bytes,2,opt,name=street1,proto3" json:"street1,omitempty"`
+	Type1   string `protobuf:"bytes,3,opt,name=type1,proto3" json:"type1,omitempty"`
+	Suffix1 string `protobuf:"bytes,4,opt,name=suffix1,proto3" json:"suffix1,omitempty"`
+	Prefix2 string `protobuf:"bytes,5,opt,name=prefix2,proto3" json:"prefix2,omitempty"`
+	Street2 string `protobuf:"bytes,6,opt,name=street2,proto3" json:"street2,omitempty"`
+	Type2   string `protobuf:"bytes,7,opt,name=type2,proto3" json:"type2,omitempty"`
+	Suffix2 string `protobuf:"bytes,8,opt,name=suffix2,proto3" json:"suffix2,omitempty"`
+	City    string `protobuf:"bytes,9,opt,name=city,proto3" json:"city,omitempty"`
+	State   string `protobuf:"bytes,10,opt,name=state,proto3" json:"state,omitempty"`
+	Zip     string `protobuf:"bytes,11,opt,name=zip,proto3" json:"zip,omitempty"`
+	Country string `protobuf:"bytes,12,opt,name=country,proto3" json:"country,omitempty"`
+}
+
+func (x *ParsedIntersection) Reset() {
+	*x = ParsedIntersection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_v1_parser_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParsedIntersection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParsedIntersection) ProtoMessage() {}
+
+func (x *ParsedIntersection) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_v1_parser_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParsedIntersection.ProtoReflect.Descriptor instead.
+func (*ParsedIntersection) Descriptor() ([]byte, []int) {
+	return file_parser_v1_parser_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ParsedIntersection) GetPrefix1() string {
+	if x != nil {
+		return x.Prefix1
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetStreet1() string {
+	if x != nil {
+		return x.Street1
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetType1() string {
+	if x != nil {
+		return x.Type1
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetSuffix1() string {
+	if x != nil {
+		return x.Suffix1
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetPrefix2() string {
+	if x != nil {
+		return x.Prefix2
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetStreet2() string {
+	if x != nil {
+		return x.Street2
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetType2() string {
+	if x != nil {
+		return x.Type2
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetSuffix2() string {
+	if x != nil {
+		return x.Suffix2
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetZip() string {
+	if x != nil {
+		return x.Zip
+	}
+	return ""
+}
+
+func (x *ParsedIntersection) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type ParseResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type          string              `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Address       *ParsedAddress      `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Intersection  *ParsedIntersection `protobuf:"bytes,3,opt,name=intersection,proto3" json:"intersection,omitempty"`
+	Confidence    float64             `protobuf:"fixed64,4,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	RunnerUp      *ParseResult        `protobuf:"bytes,5,opt,name=runner_up,json=runnerUp,proto3" json:"runner_up,omitempty"`
+	Raw           *ParsedAddress      `protobuf:"bytes,6,opt,name=raw,proto3" json:"raw,omitempty"`
+	Partial       bool                `protobuf:"varint,7,opt,name=partial,proto3" json:"partial,omitempty"`
+	Warnings      []string            `protobuf:"bytes,8,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	DetectedType  string              `protobuf:"bytes,9,opt,name=detected_type,json=detectedType,proto3" json:"detected_type,omitempty"`
+	Undeliverable string              `protobuf:"bytes,10,opt,name=undeliverable,proto3" json:"undeliverable,omitempty"`
+	Phone         string              `protobuf:"bytes,11,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email         string              `protobuf:"bytes,12,opt,name=email,proto3" json:"email,omitempty"`
+	Notes         string              `protobuf:"bytes,13,opt,name=notes,proto3" json:"notes,omitempty"`
+}
+
+func (x *ParseResult) Reset() {
+	*x = ParseResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_v1_parser_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParseResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseResult) ProtoMessage() {}
+
+func (x *ParseResult) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_v1_parser_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseResult.ProtoReflect.Descriptor instead.
+func (*ParseResult) Descriptor() ([]byte, []int) {
+	return file_parser_v1_parser_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ParseResult) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ParseResult) GetAddress() *ParsedAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ParseResult) GetIntersection() *ParsedIntersection {
+	if x != nil {
+		return x.Intersection
+	}
+	return nil
+}
+
+func (x *ParseResult) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *ParseResult) GetRunnerUp() *ParseResult {
+	if x != nil {
+		return x.RunnerUp
+	}
+	return nil
+}
+
+func (x *ParseResult) GetRaw() *ParsedAddress {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *ParseResult) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *ParseResult) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *ParseResult) GetDetectedType() string {
+	if x != nil {
+		return x.DetectedType
+	}
+	return ""
+}
+
+func (x *ParseResult) GetUndeliverable() string {
+	if x != nil {
+		return x.Undeliverable
+	}
+	return ""
+}
+
+func (x *ParseResult) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *ParseResult) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ParseResult) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+var File_parser_v1_parser_proto protoreflect.FileDescriptor
+
+var file_parser_v1_parser_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x22, 0x3c, 0x0a, 0x0c, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x22, 0x3f, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x22, 0x5f, 0x0a, 0x11, 0x50, 0x61, 0x72, 0x73, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x22, 0x5c, 0x0a, 0x12, 0x50, 0x61, 0x72, 0x73, 0x65, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x22, 0xee, 0x04, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x69, 0x6e, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65,
+	0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x12, 0x22, 0x0a, 0x0d,
+	0x73, 0x65, 0x63, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x63, 0x55, 0x6e, 0x69, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x20, 0x0a, 0x0c, 0x73, 0x65, 0x63, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x6e, 0x75, 0x6d,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x63, 0x55, 0x6e, 0x69, 0x74, 0x4e,
+	0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x7a, 0x69, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x7a, 0x69, 0x70, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x6c, 0x75, 0x73, 0x34, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70,
+	0x6c, 0x75, 0x73, 0x34, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x5f, 0x75, 0x6e, 0x69, 0x74,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x32, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65,
+	0x63, 0x55, 0x6e, 0x69, 0x74, 0x54, 0x79, 0x70, 0x65, 0x32, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x65,
+	0x63, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x32, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x73, 0x65, 0x63, 0x55, 0x6e, 0x69, 0x74, 0x4e, 0x75, 0x6d, 0x32, 0x12, 0x17,
+	0x0a, 0x07, 0x63, 0x61, 0x72, 0x65, 0x5f, 0x6f, 0x66, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x61, 0x72, 0x65, 0x4f, 0x66, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x74, 0x74, 0x65, 0x6e,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x74, 0x74, 0x65,
+	0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x63, 0x65, 0x6c, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x63, 0x65, 0x6c, 0x12, 0x21, 0x0a,
+	0x0c, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x79, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6c, 0x6f, 0x77,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4c, 0x6f,
+	0x77, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x68, 0x69, 0x67, 0x68,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x48, 0x69,
+	0x67, 0x68, 0x22, 0xb2, 0x02, 0x0a, 0x12, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x31, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x31, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x31, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x79, 0x70, 0x65, 0x31, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x79,
+	0x70, 0x65, 0x31, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x31, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x31, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x32, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x32, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x65,
+	0x74, 0x32, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74,
+	0x32, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x32, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x32, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x66, 0x66, 0x69,
+	0x78, 0x32, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78,
+	0x32, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x7a,
+	0x69, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x7a, 0x69, 0x70, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x22, 0xdc, 0x03, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x73,
+	0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70,
+	0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x41, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x75, 0x70, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x08, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x55, 0x70, 0x12, 0x2a, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x03,
+	0x72, 0x61, 0x77, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x1a, 0x0a,
+	0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x24,
+	0x0a, 0x0d, 0x75, 0x6e, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x75, 0x6e, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x32, 0x96, 0x01, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x50, 0x61, 0x72, 0x73, 0x65, 0x72, 0x12, 0x3a, 0x0a, 0x05, 0x50, 0x61, 0x72, 0x73,
+	0x65, 0x12, 0x17, 0x2e, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0a, 0x50, 0x61, 0x72, 0x73, 0x65, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x1c, 0x2e, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x72, 0x73, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72,
+	0x73, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x61,
+	0x72, 0x73, 0x65, 0x2d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x70, 0x62, 0x3b, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_parser_v1_parser_proto_rawDescOnce sync.Once
+	file_parser_v1_parser_proto_rawDescData = file_parser_v1_parser_proto_rawDesc
+)
+
+func file_parser_v1_parser_proto_rawDescGZIP() []byte {
+	file_parser_v1_parser_proto_rawDescOnce.Do(func() {
+		file_parser_v1_parser_proto_rawDescData = protoimpl.X.CompressGZIP(file_parser_v1_parser_proto_rawDescData)
+	})
+	return file_parser_v1_parser_proto_rawDescData
+}
+
+var file_parser_v1_parser_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_parser_v1_parser_proto_goTypes = []any{
+	(*ParseRequest)(nil),       // 0: parser.v1.ParseRequest
+	(*ParseResponse)(nil),      // 1: parser.v1.ParseResponse
+	(*ParseBatchRequest)(nil),  // 2: parser.v1.ParseBatchRequest
+	(*ParseBatchResponse)(nil), // 3: parser.v1.ParseBatchResponse
+	(*ParsedAddress)(nil),      // 4: parser.v1.ParsedAddress
+	(*ParsedIntersection)(nil), // 5: parser.v1.ParsedIntersection
+	(*ParseResult)(nil),        // 6: parser.v1.ParseResult
+}
+var file_parser_v1_parser_proto_depIdxs = []int32{
+	6, // 0: parser.v1.ParseResponse.result:type_name -> parser.v1.ParseResult
+	6, // 1: parser.v1.ParseBatchResponse.results:type_name -> parser.v1.ParseResult
+	4, // 2: parser.v1.ParseResult.address:type_name -> parser.v1.ParsedAddress
+	5, // 3: parser.v1.ParseResult.intersection:type_name -> parser.v1.ParsedIntersection
+	6, // 4: parser.v1.ParseResult.runner_up:type_name -> parser.v1.ParseResult
+	4, // 5: parser.v1.ParseResult.raw:type_name -> parser.v1.ParsedAddress
+	0, // 6: parser.v1.AddressParser.Parse:input_type -> parser.v1.ParseRequest
+	2, // 7: parser.v1.AddressParser.ParseBatch:input_type -> parser.v1.ParseBatchRequest
+	1, // 8: parser.v1.AddressParser.Parse:output_type -> parser.v1.ParseResponse
+	3, // 9: parser.v1.AddressParser.ParseBatch:output_type -> parser.v1.ParseBatchResponse
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_parser_v1_parser_proto_init() }
+func file_parser_v1_parser_proto_init() {
+	if File_parser_v1_parser_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_parser_v1_parser_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ParseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_v1_parser_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ParseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_v1_parser_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ParseBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_v1_parser_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ParseBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_v1_parser_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ParsedAddress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_v1_parser_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ParsedIntersection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_v1_parser_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ParseResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_parser_v1_parser_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_parser_v1_parser_proto_goTypes,
+		DependencyIndexes: file_parser_v1_parser_proto_depIdxs,
+		MessageInfos:      file_parser_v1_parser_proto_msgTypes,
+	}.Build()
+	File_parser_v1_parser_proto = out.File
+	file_parser_v1_parser_proto_rawDesc = nil
+	file_parser_v1_parser_proto_goTypes = nil
+	file_parser_v1_parser_proto_depIdxs = nil
+}
@@ -0,0 +1,148 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v27.3.0
+// source: parser/v1/parser.proto
+
+package parserpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	AddressParser_Parse_FullMethodName      = "/parser.v1.AddressParser/Parse"
+	AddressParser_ParseBatch_FullMethodName = "/parser.v1.AddressParser/ParseBatch"
+)
+
+// AddressParserClient is the client API for AddressParser service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AddressParserClient interface {
+	Parse(ctx context.Context, in *ParseRequest, opts ...grpc.CallOption) (*ParseResponse, error)
+	ParseBatch(ctx context.Context, in *ParseBatchRequest, opts ...grpc.CallOption) (*ParseBatchResponse, error)
+}
+
+type addressParserClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAddressParserClient(cc grpc.ClientConnInterface) AddressParserClient {
+	return &addressParserClient{cc}
+}
+
+func (c *addressParserClient) Parse(ctx context.Context, in *ParseRequest, opts ...grpc.CallOption) (*ParseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ParseResponse)
+	err := c.cc.Invoke(ctx, AddressParser_Parse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressParserClient) ParseBatch(ctx context.Context, in *ParseBatchRequest, opts ...grpc.CallOption) (*ParseBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ParseBatchResponse)
+	err := c.cc.Invoke(ctx, AddressParser_ParseBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddressParserServer is the server API for AddressParser service.
+// All implementations must embed UnimplementedAddressParserServer
+// for forward compatibility
+type AddressParserServer interface {
+	Parse(context.Context, *ParseRequest) (*ParseResponse, error)
+	ParseBatch(context.Context, *ParseBatchRequest) (*ParseBatchResponse, error)
+	mustEmbedUnimplementedAddressParserServer()
+}
+
+// UnimplementedAddressParserServer must be embedded to have forward compatible implementations.
+type UnimplementedAddressParserServer struct {
+}
+
+func (UnimplementedAddressParserServer) Parse(context.Context, *ParseRequest) (*ParseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Parse not implemented")
+}
+func (UnimplementedAddressParserServer) ParseBatch(context.Context, *ParseBatchRequest) (*ParseBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseBatch not implemented")
+}
+func (UnimplementedAddressParserServer) mustEmbedUnimplementedAddressParserServer() {}
+
+// UnsafeAddressParserServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AddressParserServer will
+// result in compilation errors.
+type UnsafeAddressParserServer interface {
+	mustEmbedUnimplementedAddressParserServer()
+}
+
+func RegisterAddressParserServer(s grpc.ServiceRegistrar, srv AddressParserServer) {
+	s.RegisterService(&AddressParser_ServiceDesc, srv)
+}
+
+func _AddressParser_Parse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressParserServer).Parse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressParser_Parse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressParserServer).Parse(ctx, req.(*ParseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressParser_ParseBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressParserServer).ParseBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressParser_ParseBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressParserServer).ParseBatch(ctx, req.(*ParseBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AddressParser_ServiceDesc is the grpc.ServiceDesc for AddressParser service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AddressParser_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "parser.v1.AddressParser",
+	HandlerType: (*AddressParserServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Parse",
+			Handler:    _AddressParser_Parse_Handler,
+		},
+		{
+			MethodName: "ParseBatch",
+			Handler:    _AddressParser_ParseBatch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "parser/v1/parser.proto",
+}
@@ -0,0 +1,19 @@
+#!/bin/sh
+# Build the browser (js/wasm) version of the parser into web/static/wasm/
+set -e
+
+OUT_DIR=${OUT_DIR:-web/static/wasm}
+mkdir -p "$OUT_DIR"
+
+GOOS=js GOARCH=wasm go build -ldflags='-s -w' -o "$OUT_DIR/parser.wasm" ./cmd/wasm
+
+# wasm_exec.js moved from misc/wasm to lib/wasm in Go 1.24
+GOROOT=$(go env GOROOT)
+for f in "$GOROOT/lib/wasm/wasm_exec.js" "$GOROOT/misc/wasm/wasm_exec.js"; do
+	if [ -f "$f" ]; then
+		cp "$f" "$OUT_DIR/"
+		break
+	fi
+done
+
+echo "Built $OUT_DIR/parser.wasm"